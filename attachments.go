@@ -0,0 +1,178 @@
+package redmine
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+type attachmentResult struct {
+	Attachment Attachment `json:"attachment"`
+}
+
+type attachmentUpdate struct {
+	Filename    string `json:"filename,omitempty"`
+	Description string `json:"description"`
+}
+
+type attachmentRequest struct {
+	Attachment attachmentUpdate `json:"attachment"`
+}
+
+// Attachment is a file attached to an issue, a wiki page or a project.
+type Attachment struct {
+	Id           int      `json:"id"`
+	Filename     string   `json:"filename"`
+	Filesize     int64    `json:"filesize"`
+	ContentType  string   `json:"content_type"`
+	Description  string   `json:"description"`
+	ContentURL   string   `json:"content_url"`
+	ThumbnailURL string   `json:"thumbnail_url,omitempty"`
+	Digest       string   `json:"digest,omitempty"`
+	Author       *IdName  `json:"author"`
+	CreatedOn    DateTime `json:"created_on"`
+}
+
+func (c *Client) Attachment(id int) (*Attachment, error) {
+	return c.AttachmentContext(context.Background(), id)
+}
+
+func (c *Client) AttachmentContext(ctx context.Context, id int) (*Attachment, error) {
+	res, err := c.get(ctx, c.endpoint+"/attachments/"+strconv.Itoa(id)+".json")
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	decoder := json.NewDecoder(res.Body)
+	var r attachmentResult
+	if res.StatusCode != 200 {
+		err = errorFromResp(res)
+	} else {
+		err = decoder.Decode(&r)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &r.Attachment, nil
+}
+
+// DownloadAttachment writes the content of a to w. When Redmine reports a
+// digest for a, the content is checked against it and an error matching
+// ErrDigestMismatch is returned if they differ, after the whole content
+// was written to w.
+//
+// The content is always downloaded from the client's endpoint, whatever
+// host a.ContentURL names, so that credentials are not sent elsewhere. If
+// a has no ContentURL, e.g. an Attachment{Id: id}, its metadata is fetched
+// first.
+func (c *Client) DownloadAttachment(a *Attachment, w io.Writer) error {
+	return c.DownloadAttachmentContext(context.Background(), a, w)
+}
+
+// DownloadAttachmentContext is like DownloadAttachment but uses ctx for the
+// request.
+func (c *Client) DownloadAttachmentContext(ctx context.Context, a *Attachment, w io.Writer) error {
+	if a.ContentURL == "" {
+		var err error
+		if a, err = c.AttachmentContext(ctx, a.Id); err != nil {
+			return err
+		}
+	}
+	uri := c.endpoint + "/attachments/download/" + strconv.Itoa(a.Id)
+	if a.Filename != "" {
+		uri += "/" + url.PathEscape(a.Filename)
+	}
+	res, err := c.get(ctx, uri)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != 200 {
+		return errorFromResp(res)
+	}
+	var h hash.Hash
+	switch len(a.Digest) {
+	case 2 * md5.Size:
+		h = md5.New()
+	case 2 * sha256.Size:
+		h = sha256.New()
+	}
+	if h != nil {
+		w = io.MultiWriter(w, h)
+	}
+	if _, err := io.Copy(w, res.Body); err != nil {
+		return err
+	}
+	if h != nil {
+		if sum := hex.EncodeToString(h.Sum(nil)); !strings.EqualFold(sum, a.Digest) {
+			return fmt.Errorf("%w: attachment %d has digest %s, want %s", ErrDigestMismatch, a.Id, sum, a.Digest)
+		}
+	}
+	return nil
+}
+
+// UpdateAttachment changes the filename and the description of the
+// attachment. An empty filename is left unchanged.
+func (c *Client) UpdateAttachment(a Attachment) error {
+	return c.UpdateAttachmentContext(context.Background(), a)
+}
+
+// UpdateAttachmentContext is like UpdateAttachment but uses ctx for the
+// request.
+func (c *Client) UpdateAttachmentContext(ctx context.Context, a Attachment) error {
+	var ar attachmentRequest
+	ar.Attachment.Filename = a.Filename
+	ar.Attachment.Description = a.Description
+	s, err := json.Marshal(ar)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, "PATCH", c.endpoint+"/attachments/"+strconv.Itoa(a.Id)+".json", strings.NewReader(string(s)))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode/100 != 2 {
+		return errorFromResp(res)
+	}
+	return nil
+}
+
+func (c *Client) DeleteAttachment(id int) error {
+	return c.DeleteAttachmentContext(context.Background(), id)
+}
+
+func (c *Client) DeleteAttachmentContext(ctx context.Context, id int) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", c.endpoint+"/attachments/"+strconv.Itoa(id)+".json", strings.NewReader(""))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode/100 != 2 {
+		return errorFromResp(res)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package redmine
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDownloadAttachmentStaysOnEndpoint(t *testing.T) {
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("request sent to content_url host: %s", r.URL)
+	}))
+	defer other.Close()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/attachments/1.json":
+			io.WriteString(w, `{"attachment":{"id":1,"filename":"a b.txt","content_url":"`+other.URL+`/attachments/download/1/a%20b.txt","digest":"5d41402abc4b2a76b9719d911017c592"}}`)
+		case "/attachments/download/1/a b.txt":
+			io.WriteString(w, "hello")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "key")
+	a, err := c.Attachment(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b bytes.Buffer
+	if err := c.DownloadAttachment(a, &b); err != nil || b.String() != "hello" {
+		t.Errorf("got %q, %v", b.String(), err)
+	}
+
+	// without metadata
+	b.Reset()
+	if err := c.DownloadAttachment(&Attachment{Id: 1}, &b); err != nil || b.String() != "hello" {
+		t.Errorf("got %q, %v", b.String(), err)
+	}
+}
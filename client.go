@@ -1,77 +1,217 @@
 package redmine
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
-	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strconv"
-	"strings"
+	"time"
 )
 
 type Client struct {
-	endpoint string
-	apikey   string
+	endpoint   string
+	apikey     string
+	login      string
+	password   string
+	switchUser string
+	userAgent  string
+	timeout    time.Duration
+	logger     Logger
 	*http.Client
+	// Limit and Offset are the page size and start offset of list calls,
+	// -1 for the server defaults.
+	//
+	// Deprecated: changing them while the client is in use is a data race.
+	// Use WithPageSize or pass Limit and Offset options to the list calls.
 	Limit  int
 	Offset int
+
+	// PageWorkers is the number of pages issue listings fetch concurrently
+	// once the first page revealed the total count. Zero or one fetches
+	// pages one after another.
+	PageWorkers int
+
+	// Retry, when set, makes failed requests be retried, see RetryPolicy.
+	Retry *RetryPolicy
+
+	// Throttle, when set, limits the rate and concurrency of requests.
+	Throttle *Throttle
 }
 
-var DefaultLimit int = -1  // "-1" means "No setting"
-var DefaultOffset int = -1 //"-1" means "No setting"
+// DefaultLimit and DefaultOffset are the initial Limit and Offset of new
+// clients, -1 for the server defaults.
+//
+// Deprecated: they are shared by every client of the program. Use
+// WithPageSize, or pass Limit and Offset options to the list calls.
+var (
+	DefaultLimit  int = -1
+	DefaultOffset int = -1
+)
+
+// NewClient returns a client for the Redmine instance at endpoint
+// authenticating with apikey, configured by opts.
+func NewClient(endpoint, apikey string, opts ...Option) *Client {
+	c := &Client{
+		endpoint: endpoint,
+		apikey:   apikey,
+		Client:   http.DefaultClient,
+		Limit:    DefaultLimit,
+		Offset:   DefaultOffset,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.timeout > 0 {
+		hc := *c.Client
+		hc.Timeout = c.timeout
+		c.Client = &hc
+	}
+	return c
+}
 
-func NewClient(endpoint, apikey string) *Client {
-	return &Client{endpoint, apikey, http.DefaultClient, DefaultLimit, DefaultOffset}
+// As returns a copy of c that acts on behalf of the user with the given
+// login through the X-Redmine-Switch-User header. It shares the HTTP client,
+// retry policy and throttle of c. Impersonation requires an administrator
+// account.
+func (c *Client) As(login string) *Client {
+	cc := *c
+	cc.switchUser = login
+	return &cc
 }
 
 // URLWithFilter return string url by concat endpoint, path and filter
 // err != nil when endpoin can not parse
 func (c *Client) URLWithFilter(path string, f Filter) (string, error) {
-	var fullURL *url.URL
+	params := f.clone()
+	if c.Limit > -1 {
+		params.AddPair("limit", strconv.Itoa(c.Limit))
+	}
+	if c.Offset > -1 {
+		params.AddPair("offset", strconv.Itoa(c.Offset))
+	}
+	return c.url(path, params)
+}
+
+// url returns the full URL of path on the endpoint with the parameters of f.
+func (c *Client) url(path string, f *Filter) (string, error) {
 	fullURL, err := url.Parse(c.endpoint)
 	if err != nil {
 		return "", err
 	}
 	fullURL.Path += path
-	if c.Limit > -1 {
-		f.AddPair("limit", strconv.Itoa(c.Limit))
-	}
-	if c.Offset > -1 {
-		f.AddPair("offset", strconv.Itoa(c.Offset))
-	}
 	fullURL.RawQuery = f.ToURLParams()
 	return fullURL.String(), nil
 }
 
-func (c *Client) getPaginationClause() string {
-	clause := ""
-	if c.Limit > -1 {
-		clause = clause + fmt.Sprintf("&limit=%v", c.Limit)
-	}
-	if c.Offset > -1 {
-		clause = clause + fmt.Sprintf("&offset=%v", c.Offset)
+// get issues a GET request for uri bound to ctx.
+func (c *Client) get(ctx context.Context, uri string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", uri, nil)
+	if err != nil {
+		return nil, err
 	}
-	return clause
+	return c.do(req)
 }
 
-type errorsResult struct {
-	Errors []string `json:"errors"`
+// do authenticates and sends req, retrying it according to c.Retry.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	if c.login != "" {
+		req.SetBasicAuth(c.login, c.password)
+	} else if c.apikey != "" {
+		req.Header.Set("X-Redmine-API-Key", c.apikey)
+	}
+	if c.switchUser != "" {
+		req.Header.Set("X-Redmine-Switch-User", c.switchUser)
+	}
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+	if c.Retry == nil || !c.Retry.allows(req) {
+		return c.send(req)
+	}
+	ctx := req.Context()
+	for retry := 1; ; retry++ {
+		res, err := c.send(req)
+		if retry >= c.Retry.MaxAttempts || !shouldRetry(res, err) || ctx.Err() != nil {
+			return res, err
+		}
+		wait, ok := c.Retry.backoff(retry, res)
+		if !ok {
+			return res, err
+		}
+		if res != nil {
+			io.Copy(ioutil.Discard, res.Body)
+			res.Body.Close()
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+		if req.GetBody != nil {
+			if req.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+	}
 }
 
-func errorFromResp(bodyDecoder *json.Decoder, httpStatus int) (err error) {
-	var er errorsResult
-	err = bodyDecoder.Decode(&er)
-	if err == nil { /* error from redmine */
-		return errors.New(strings.Join(er.Errors, "\n"))
+// send sends req once, after waiting for c.Throttle.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	release := func() {}
+	if c.Throttle != nil {
+		var err error
+		if release, err = c.Throttle.acquire(req.Context()); err != nil {
+			return nil, err
+		}
 	}
-
-	if err == io.EOF { /* empty body */
-		err = errors.New(http.StatusText(httpStatus))
+	start := time.Now()
+	hc := *c.Client
+	hc.CheckRedirect = keepKeyOnHost(hc.CheckRedirect)
+	res, err := hc.Do(req)
+	if ue, ok := err.(*url.Error); ok {
+		// the endpoint or a filter may still carry credentials
+		ue.URL = redactURL(req.URL)
+	}
+	if c.logger != nil {
+		if err != nil {
+			c.logger.Printf("redmine: %s %s: %v", req.Method, redactURL(req.URL), err)
+		} else {
+			c.logger.Printf("redmine: %s %s: %s (%v)", req.Method, redactURL(req.URL), res.Status, time.Since(start))
+		}
+	}
+	if err != nil {
+		release()
+		return nil, err
+	}
+	if c.Throttle != nil {
+		res.Body = &releaseBody{res.Body, release}
 	}
+	return res, nil
+}
 
-	return
+// keepKeyOnHost wraps the redirect policy check so that the API key is not
+// sent to another host or over plain HTTP. net/http only strips the
+// Authorization header on such redirects.
+func keepKeyOnHost(check func(*http.Request, []*http.Request) error) func(*http.Request, []*http.Request) error {
+	return func(req *http.Request, via []*http.Request) error {
+		orig := via[0].URL
+		if req.URL.Host != orig.Host || (orig.Scheme == "https" && req.URL.Scheme != "https") {
+			req.Header.Del("X-Redmine-API-Key")
+		}
+		if check != nil {
+			return check(req, via)
+		}
+		// the default policy of http.Client
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+		return nil
+	}
 }
 
 type IdName struct {
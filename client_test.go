@@ -0,0 +1,38 @@
+package redmine
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRedirectKeepsKeyOnHost(t *testing.T) {
+	keys := make(map[string]string)
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys["other"] = r.Header.Get("X-Redmine-API-Key")
+		io.WriteString(w, `{"issue":{"id":1}}`)
+	}))
+	defer other.Close()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/issues/1.json":
+			http.Redirect(w, r, "/moved/1.json", http.StatusFound)
+		case "/moved/1.json":
+			keys["same"] = r.Header.Get("X-Redmine-API-Key")
+			http.Redirect(w, r, other.URL+"/issues/1.json", http.StatusFound)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "secret")
+	if _, err := c.Issue(1); err != nil {
+		t.Fatal(err)
+	}
+	if keys["same"] != "secret" {
+		t.Errorf("key on the same host = %q, want secret", keys["same"])
+	}
+	if keys["other"] != "" {
+		t.Errorf("key sent to another host: %q", keys["other"])
+	}
+}
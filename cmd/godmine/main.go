@@ -388,6 +388,15 @@ func listIssues(filter *redmine.IssueFilter) {
 	}
 }
 
+func listQueryIssues(name string) {
+	c := redmine.NewClient(conf.Endpoint, conf.Apikey)
+	query, err := c.QueryByName(conf.Project, name)
+	if err != nil {
+		fatal("Failed to find query: %s\n", err)
+	}
+	listIssues(query.IssueFilter(conf.Project))
+}
+
 func addProject(name, identifier, description string) {
 	var project redmine.Project
 	c := redmine.NewClient(conf.Endpoint, conf.Apikey)
@@ -665,7 +674,7 @@ func editWikiPage(title string) error {
 	c := redmine.NewClient(conf.Endpoint, conf.Apikey)
 	page, err := c.WikiPage(conf.Project, title)
 	if err != nil {
-		if err.Error() != "Not Found" {
+		if !errors.Is(err, redmine.ErrNotFound) {
 			return fmt.Errorf("Failed to read wiki page for editing: %s\n", err)
 		}
 		page = &redmine.WikiPage{Title: title}
@@ -854,6 +863,9 @@ Issue Commands:
   list     l listing issues.
              $ godmine i l
 
+  query    q listing issues of the saved query with given name.
+             $ godmine i q 'Release blockers'
+
 Membership Commands:
   show     s show given membership.
              $ godmine m s 1
@@ -1035,6 +1047,13 @@ func main() {
 			}
 			listIssues(filter)
 			break
+		case "q", "query":
+			if flag.NArg() == 3 {
+				listQueryIssues(flag.Arg(2))
+			} else {
+				usage()
+			}
+			break
 		default:
 			usage()
 		}
@@ -0,0 +1,88 @@
+package redmine
+
+import (
+	"bytes"
+	"time"
+)
+
+const (
+	dateLayout     = "2006-01-02"
+	dateTimeLayout = time.RFC3339
+)
+
+// Date is a calendar date such as the due date of an issue. The zero Date
+// stands for no date; it is encoded as null and decoded from null or "".
+type Date struct {
+	time.Time
+}
+
+// NewDate returns the Date of the given day.
+func NewDate(year int, month time.Month, day int) Date {
+	return Date{time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+// ParseDate parses a date in Redmine's YYYY-MM-DD format.
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse(dateLayout, s)
+	return Date{t}, err
+}
+
+// String returns d in the YYYY-MM-DD format, or "" for the zero Date.
+func (d Date) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.Format(dateLayout)
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+	return []byte(`"` + d.Format(dateLayout) + `"`), nil
+}
+
+func (d *Date) UnmarshalJSON(b []byte) error {
+	t, err := parseJSONTime(b, dateLayout)
+	d.Time = t
+	return err
+}
+
+// DateTime is a point in time such as the creation time of an issue. The
+// zero DateTime stands for no time; it is encoded as null and decoded from
+// null or "".
+type DateTime struct {
+	time.Time
+}
+
+// String returns t in the RFC 3339 format used by Redmine, or "" for the
+// zero DateTime.
+func (t DateTime) String() string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(dateTimeLayout)
+}
+
+func (t DateTime) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return []byte(`"` + t.Format(dateTimeLayout) + `"`), nil
+}
+
+func (t *DateTime) UnmarshalJSON(b []byte) error {
+	tt, err := parseJSONTime(b, dateTimeLayout)
+	t.Time = tt
+	return err
+}
+
+func parseJSONTime(b []byte, layout string) (time.Time, error) {
+	if bytes.Equal(b, []byte("null")) || bytes.Equal(b, []byte(`""`)) {
+		return time.Time{}, nil
+	}
+	if len(b) < 2 || b[0] != '"' || b[len(b)-1] != '"' {
+		return time.Time{}, &time.ParseError{Layout: layout, Value: string(b), Message: ": not a JSON string"}
+	}
+	return time.Parse(layout, string(b[1:len(b)-1]))
+}
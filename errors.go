@@ -0,0 +1,116 @@
+package redmine
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Sentinel errors matched through errors.Is.
+var (
+	ErrUnauthorized  = errors.New("redmine: unauthorized")
+	ErrForbidden     = errors.New("redmine: forbidden")
+	ErrNotFound      = errors.New("redmine: not found")
+	ErrUnprocessable = errors.New("redmine: unprocessable entity")
+	ErrConflict      = errors.New("redmine: conflict")
+
+	// ErrDigestMismatch is returned by DownloadAttachment when the
+	// downloaded content does not match the digest reported by Redmine.
+	ErrDigestMismatch = errors.New("redmine: digest mismatch")
+)
+
+// Error is returned when Redmine answers a request with an unexpected
+// status code.
+type Error struct {
+	StatusCode int
+	Method     string
+	URL        string   // request URL with credentials redacted
+	Errors     []string // validation messages reported by Redmine
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("%s %s: %d %s", e.Method, e.URL, e.StatusCode, http.StatusText(e.StatusCode))
+	if len(e.Errors) > 0 {
+		msg += ": " + strings.Join(e.Errors, ", ")
+	}
+	return msg
+}
+
+// Is reports whether target is the sentinel error for e's status code.
+func (e *Error) Is(target error) bool {
+	switch e.StatusCode {
+	case http.StatusUnauthorized:
+		return target == ErrUnauthorized
+	case http.StatusForbidden:
+		return target == ErrForbidden
+	case http.StatusNotFound:
+		return target == ErrNotFound
+	case http.StatusUnprocessableEntity:
+		return target == ErrUnprocessable
+	}
+	return false
+}
+
+// ConflictError is returned by UpdateIssueIfUnmodified and
+// UpdateIssueMerging when the issue was changed by someone else since it
+// was read. It matches ErrConflict through errors.Is.
+type ConflictError struct {
+	IssueId   int
+	UpdatedOn DateTime   // the time the issue was last updated
+	Journals  []*Journal // the changes made since the issue was read
+	Fields    []string   // the attributes changed on both sides, if merging
+}
+
+func (e *ConflictError) Error() string {
+	msg := fmt.Sprintf("redmine: issue %d was updated on %s", e.IssueId, e.UpdatedOn)
+	if len(e.Fields) > 0 {
+		msg += ": " + strings.Join(e.Fields, ", ") + " changed"
+	}
+	return msg
+}
+
+func (e *ConflictError) Is(target error) bool {
+	return target == ErrConflict
+}
+
+type errorsResult struct {
+	Errors []string `json:"errors"`
+}
+
+// errorFromResp builds an *Error from a non-successful response. The body
+// is decoded on a best effort basis since Redmine does not always answer
+// with JSON.
+func errorFromResp(res *http.Response) error {
+	e := &Error{StatusCode: res.StatusCode}
+	if res.Request != nil {
+		e.Method = res.Request.Method
+		e.URL = redactURL(res.Request.URL)
+	}
+	var er errorsResult
+	if json.NewDecoder(res.Body).Decode(&er) == nil {
+		e.Errors = er.Errors
+	}
+	return e
+}
+
+// redactURL returns u as a string with the API key and any password
+// replaced, so it can be logged safely.
+func redactURL(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+	r := *u
+	if r.User != nil {
+		if _, ok := r.User.Password(); ok {
+			r.User = url.UserPassword(r.User.Username(), "xxxxx")
+		}
+	}
+	if q := r.Query(); q.Get("key") != "" {
+		q.Set("key", "xxxxx")
+		r.RawQuery = q.Encode()
+	}
+	return r.String()
+}
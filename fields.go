@@ -0,0 +1,55 @@
+package redmine
+
+import "encoding/json"
+
+// attributes is the attribute set embedded by the write payloads, such as
+// IssueFields and UserFields. Only the attributes which are set are
+// marshalled, so that an update leaves the others unchanged.
+type attributes struct {
+	fields       map[string]interface{}
+	customFields []customFieldValue
+}
+
+type customFieldValue struct {
+	Id    int         `json:"id"`
+	Value interface{} `json:"value"`
+}
+
+func (a *attributes) set(name string, value interface{}) {
+	if a.fields == nil {
+		a.fields = make(map[string]interface{})
+	}
+	a.fields[name] = value
+}
+
+func (a *attributes) unset(name string) {
+	delete(a.fields, name)
+}
+
+func (a *attributes) has(name string) bool {
+	_, ok := a.fields[name]
+	return ok
+}
+
+func (a *attributes) setCustomField(id int, value interface{}) {
+	for i := range a.customFields {
+		if a.customFields[i].Id == id {
+			a.customFields[i].Value = value
+			return
+		}
+	}
+	a.customFields = append(a.customFields, customFieldValue{Id: id, Value: value})
+}
+
+// MarshalJSON marshals the attributes which are set, custom fields under
+// "custom_fields".
+func (a *attributes) MarshalJSON() ([]byte, error) {
+	m := make(map[string]interface{}, len(a.fields)+1)
+	for k, v := range a.fields {
+		m[k] = v
+	}
+	if len(a.customFields) > 0 {
+		m["custom_fields"] = a.customFields
+	}
+	return json.Marshal(m)
+}
@@ -0,0 +1,89 @@
+package redmine
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type projectFilesResult struct {
+	Files []ProjectFile `json:"files"`
+	page
+}
+
+type projectFileRequest struct {
+	File ProjectFileUpload `json:"file"`
+}
+
+// ProjectFile is a file published in the Files tab of a project.
+type ProjectFile struct {
+	Attachment
+	Version   *IdName `json:"version"`
+	Downloads int     `json:"downloads"`
+}
+
+// ProjectFileUpload publishes a file uploaded with Upload or UploadReader,
+// see CreateProjectFile.
+type ProjectFileUpload struct {
+	Token       string `json:"token"`
+	VersionId   int    `json:"version_id,omitempty"`
+	Filename    string `json:"filename,omitempty"` // defaults to the uploaded file name
+	Description string `json:"description,omitempty"`
+}
+
+func (c *Client) ProjectFiles(projectId int) ([]ProjectFile, error) {
+	return c.ProjectFilesContext(context.Background(), projectId)
+}
+
+func (c *Client) ProjectFilesContext(ctx context.Context, projectId int, opts ...ListOption) ([]ProjectFile, error) {
+	var list []ProjectFile
+	err := c.getPages(ctx, "/projects/"+strconv.Itoa(projectId)+"/files.json", nil, opts, func(decoder *json.Decoder) (page, int, error) {
+		var r projectFilesResult
+		err := decoder.Decode(&r)
+		list = append(list, r.Files...)
+		return r.page, len(r.Files), err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// CreateProjectFile publishes an uploaded file in the project with the
+// given id:
+//
+//	u, err := c.Upload("dist/app-1.2.0.tar.gz")
+//	...
+//	err = c.CreateProjectFile(projectId, redmine.ProjectFileUpload{
+//		Token:     u.Token,
+//		VersionId: versionId,
+//	})
+func (c *Client) CreateProjectFile(projectId int, file ProjectFileUpload) error {
+	return c.CreateProjectFileContext(context.Background(), projectId, file)
+}
+
+// CreateProjectFileContext is like CreateProjectFile but uses ctx for the
+// request.
+func (c *Client) CreateProjectFileContext(ctx context.Context, projectId int, file ProjectFileUpload) error {
+	s, err := json.Marshal(projectFileRequest{File: file})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", c.endpoint+"/projects/"+strconv.Itoa(projectId)+"/files.json", strings.NewReader(string(s)))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode/100 != 2 {
+		return errorFromResp(res)
+	}
+	return nil
+}
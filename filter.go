@@ -1,39 +1,83 @@
 package redmine
 
-import "strings"
+import "net/url"
 
+// Filter holds the query parameters of a request. Values are escaped when
+// the filter is encoded, so they must be given unescaped, and a key may
+// hold several values, e.g. "f[]" in Redmine's issue filters.
 type Filter struct {
-	filters map[string]string
+	values url.Values
 }
 
+// NewFilter returns a filter holding the given key and value pairs. Keys
+// given more than once keep all their values.
 func NewFilter(args ...string) *Filter {
 	f := &Filter{}
 	if len(args)%2 == 0 {
 		for i := 0; i < len(args); i += 2 {
-			f.AddPair(args[i], args[i+1])
+			f.Add(args[i], args[i+1])
 		}
 	}
 	return f
 }
 
+// AddPair sets key to value, replacing any previous values of key.
 func (f *Filter) AddPair(key, value string) {
-	if f.filters == nil {
-		f.filters = make(map[string]string)
+	f.Set(key, value)
+}
+
+// Set sets key to value, replacing any previous values of key.
+func (f *Filter) Set(key, value string) {
+	if f.values == nil {
+		f.values = make(url.Values)
 	}
-	f.filters[key] = encode4Redmine(value)
+	f.values.Set(key, value)
 }
 
-func (f *Filter) ToURLParams() string {
-	params := ""
-	for k, v := range f.filters {
-		params += "&" + k + "=" + v
+// Add appends value to the values of key.
+func (f *Filter) Add(key, value string) {
+	if f.values == nil {
+		f.values = make(url.Values)
+	}
+	f.values.Add(key, value)
+}
+
+// Del removes all values of key.
+func (f *Filter) Del(key string) {
+	if f.values != nil {
+		f.values.Del(key)
+	}
+}
+
+// Get returns the first value of key, or "" if there is none.
+func (f *Filter) Get(key string) string {
+	if f == nil {
+		return ""
 	}
-	return params
+	return f.values.Get(key)
 }
 
-func encode4Redmine(s string) string {
-	a := strings.Replace(s, ">", "%3E", -1)
-	a = strings.Replace(a, "<", "%3C", -1)
-	a = strings.Replace(a, "=", "%3D", -1)
-	return a
+// Values returns a copy of the parameters of f.
+func (f *Filter) Values() url.Values {
+	return f.clone().values
+}
+
+// clone returns a copy of f that can be modified without affecting f.
+func (f *Filter) clone() *Filter {
+	c := &Filter{values: make(url.Values)}
+	if f != nil {
+		for k, v := range f.values {
+			c.values[k] = append([]string(nil), v...)
+		}
+	}
+	return c
+}
+
+// ToURLParams encodes f as a URL query. Keys are sorted so that the same
+// filter always gives the same query.
+func (f *Filter) ToURLParams() string {
+	if f == nil {
+		return ""
+	}
+	return f.values.Encode()
 }
@@ -0,0 +1,238 @@
+package redmine
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type groupsResult struct {
+	Groups []Group `json:"groups"`
+	page
+}
+
+type groupResult struct {
+	Group Group `json:"group"`
+}
+
+type groupRequest struct {
+	Group Group `json:"group"`
+}
+
+type Group struct {
+	Id           int            `json:"id"`
+	Name         string         `json:"name"`
+	UserIds      []int          `json:"user_ids,omitempty"` // members to set on create or update
+	Users        []IdName       `json:"users,omitempty"`
+	Memberships  []Membership   `json:"memberships,omitempty"`
+	CustomFields []*CustomField `json:"custom_fields,omitempty"`
+}
+
+const (
+	GroupIncludeUsers       string = "users"
+	GroupIncludeMemberships string = "memberships"
+)
+
+func (c *Client) Groups() ([]Group, error) {
+	return c.GroupsContext(context.Background())
+}
+
+func (c *Client) GroupsContext(ctx context.Context, opts ...ListOption) ([]Group, error) {
+	var list []Group
+	err := c.getPages(ctx, "/groups.json", nil, opts, func(decoder *json.Decoder) (page, int, error) {
+		var r groupsResult
+		err := decoder.Decode(&r)
+		list = append(list, r.Groups...)
+		return r.page, len(r.Groups), err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (c *Client) Group(id int) (*Group, error) {
+	return c.GroupContext(context.Background(), id)
+}
+
+func (c *Client) GroupContext(ctx context.Context, id int) (*Group, error) {
+	return c.GroupWithIncludeContext(ctx, id)
+}
+
+// GroupWithInclude returns the group with the given id along with its
+// users or memberships, see GroupIncludeUsers and GroupIncludeMemberships.
+func (c *Client) GroupWithInclude(id int, include ...string) (*Group, error) {
+	return c.GroupWithIncludeContext(context.Background(), id, include...)
+}
+
+// GroupWithIncludeContext is like GroupWithInclude but uses ctx for the
+// request.
+func (c *Client) GroupWithIncludeContext(ctx context.Context, id int, include ...string) (*Group, error) {
+	f := NewFilter()
+	if len(include) > 0 {
+		f.Set("include", strings.Join(include, ","))
+	}
+	url, err := c.url("/groups/"+strconv.Itoa(id)+".json", f)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.get(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	decoder := json.NewDecoder(res.Body)
+	var r groupResult
+	if res.StatusCode != 200 {
+		err = errorFromResp(res)
+	} else {
+		err = decoder.Decode(&r)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &r.Group, nil
+}
+
+func (c *Client) CreateGroup(group Group) (*Group, error) {
+	return c.CreateGroupContext(context.Background(), group)
+}
+
+func (c *Client) CreateGroupContext(ctx context.Context, group Group) (*Group, error) {
+	var gr groupRequest
+	gr.Group = group
+	s, err := json.Marshal(gr)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", c.endpoint+"/groups.json", strings.NewReader(string(s)))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	decoder := json.NewDecoder(res.Body)
+	var r groupResult
+	if res.StatusCode != 201 {
+		err = errorFromResp(res)
+	} else {
+		err = decoder.Decode(&r)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &r.Group, nil
+}
+
+// UpdateGroup renames the group. When group.UserIds is not empty, it also
+// replaces the users of the group.
+func (c *Client) UpdateGroup(group Group) error {
+	return c.UpdateGroupContext(context.Background(), group)
+}
+
+func (c *Client) UpdateGroupContext(ctx context.Context, group Group) error {
+	var gr groupRequest
+	gr.Group = group
+	s, err := json.Marshal(gr)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, "PUT", c.endpoint+"/groups/"+strconv.Itoa(group.Id)+".json", strings.NewReader(string(s)))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode/100 != 2 {
+		return errorFromResp(res)
+	}
+	return nil
+}
+
+func (c *Client) DeleteGroup(id int) error {
+	return c.DeleteGroupContext(context.Background(), id)
+}
+
+func (c *Client) DeleteGroupContext(ctx context.Context, id int) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", c.endpoint+"/groups/"+strconv.Itoa(id)+".json", strings.NewReader(""))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode/100 != 2 {
+		return errorFromResp(res)
+	}
+	return nil
+}
+
+// AddGroupUser adds the user with the given id to the group.
+func (c *Client) AddGroupUser(groupId, userId int) error {
+	return c.AddGroupUserContext(context.Background(), groupId, userId)
+}
+
+// AddGroupUserContext is like AddGroupUser but uses ctx for the request.
+func (c *Client) AddGroupUserContext(ctx context.Context, groupId, userId int) error {
+	s, err := json.Marshal(userIdRequest{UserId: userId})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", c.endpoint+"/groups/"+strconv.Itoa(groupId)+"/users.json", strings.NewReader(string(s)))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode/100 != 2 {
+		return errorFromResp(res)
+	}
+	return nil
+}
+
+// RemoveGroupUser removes the user with the given id from the group.
+func (c *Client) RemoveGroupUser(groupId, userId int) error {
+	return c.RemoveGroupUserContext(context.Background(), groupId, userId)
+}
+
+// RemoveGroupUserContext is like RemoveGroupUser but uses ctx for the
+// request.
+func (c *Client) RemoveGroupUserContext(ctx context.Context, groupId, userId int) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", c.endpoint+"/groups/"+strconv.Itoa(groupId)+"/users/"+strconv.Itoa(userId)+".json", strings.NewReader(""))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode/100 != 2 {
+		return errorFromResp(res)
+	}
+	return nil
+}
@@ -1,12 +1,12 @@
 package redmine
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
-	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
+	"sync"
 )
 
 type issueRequest struct {
@@ -18,10 +18,8 @@ type issueResult struct {
 }
 
 type issuesResult struct {
-	Issues     []Issue `json:"issues"`
-	TotalCount uint    `json:"total_count"`
-	Offset     uint    `json:"offset"`
-	Limit      uint    `json:"limit"`
+	Issues []Issue `json:"issues"`
+	page
 }
 
 type JournalDetails struct {
@@ -34,7 +32,7 @@ type Journal struct {
 	Id        int              `json:"id"`
 	User      *IdName          `json:"user"`
 	Notes     string           `json:"notes"`
-	CreatedOn string           `json:"created_on"`
+	CreatedOn DateTime         `json:"created_on"`
 	Details   []JournalDetails `json:"details"`
 }
 
@@ -59,17 +57,73 @@ type Issue struct {
 	Category       *IdName        `json:"category"`
 	CategoryId     int            `json:"category_id,omitempty"`
 	Notes          string         `json:"notes"`
-	StatusDate     string         `json:"status_date"`
-	CreatedOn      string         `json:"created_on"`
-	UpdatedOn      string         `json:"updated_on"`
-	StartDate      string         `json:"start_date"`
-	DueDate        string         `json:"due_date"`
-	ClosedOn       string         `json:"closed_on"`
+	StatusDate     DateTime       `json:"status_date"`
+	CreatedOn      DateTime       `json:"created_on"`
+	UpdatedOn      DateTime       `json:"updated_on"`
+	StartDate      Date           `json:"start_date"`
+	DueDate        Date           `json:"due_date"`
+	ClosedOn       DateTime       `json:"closed_on"`
 	CustomFields   []*CustomField `json:"custom_fields,omitempty"`
 	Uploads        []*Upload      `json:"uploads"`
 	DoneRatio      float32        `json:"done_ratio"`
 	EstimatedHours float32        `json:"estimated_hours"`
 	Journals       []*Journal     `json:"journals"`
+
+	IsPrivate           bool             `json:"is_private,omitempty"`
+	SpentHours          float64          `json:"spent_hours,omitempty"`
+	TotalSpentHours     float64          `json:"total_spent_hours,omitempty"`
+	TotalEstimatedHours float64          `json:"total_estimated_hours,omitempty"`
+	Children            []*IssueChild    `json:"children,omitempty"`
+	Attachments         []*Attachment    `json:"attachments,omitempty"`
+	Relations           []*IssueRelation `json:"relations,omitempty"`
+	Changesets          []*Changeset     `json:"changesets,omitempty"`
+	Watchers            []*IdName        `json:"watchers,omitempty"`
+	AllowedStatuses     []*IssueStatus   `json:"allowed_statuses,omitempty"`
+
+	// WatcherUserIds are the users watching a new issue, see CreateIssue.
+	WatcherUserIds []int `json:"watcher_user_ids,omitempty"`
+}
+
+// IssueChild is a subtask of an issue, see IncludeChildren.
+type IssueChild struct {
+	Id       int           `json:"id"`
+	Tracker  *IdName       `json:"tracker"`
+	Subject  string        `json:"subject"`
+	Children []*IssueChild `json:"children,omitempty"`
+}
+
+// Changeset is a repository commit referring to an issue, see
+// IncludeChangesets.
+type Changeset struct {
+	Revision    string   `json:"revision"`
+	User        *IdName  `json:"user"`
+	Comments    string   `json:"comments"`
+	CommittedOn DateTime `json:"committed_on"`
+}
+
+// IssueInclude is associated data which can be requested along with an
+// issue, see IssueWithInclude.
+type IssueInclude string
+
+const (
+	IncludeChildren        IssueInclude = "children"
+	IncludeAttachments     IssueInclude = "attachments"
+	IncludeRelations       IssueInclude = "relations"
+	IncludeChangesets      IssueInclude = "changesets"
+	IncludeJournals        IssueInclude = "journals"
+	IncludeWatchers        IssueInclude = "watchers"
+	IncludeAllowedStatuses IssueInclude = "allowed_statuses"
+)
+
+// IncludeAll is every IssueInclude.
+var IncludeAll = []IssueInclude{
+	IncludeChildren,
+	IncludeAttachments,
+	IncludeRelations,
+	IncludeChangesets,
+	IncludeJournals,
+	IncludeWatchers,
+	IncludeAllowedStatuses,
 }
 
 type IssueFilter struct {
@@ -80,6 +134,11 @@ type IssueFilter struct {
 	AssignedToId string
 	UpdatedOn    string
 	ExtraFilters map[string]string
+
+	// Params holds further parameters, which unlike ExtraFilters may be
+	// repeated, such as the f[], op[field] and v[field][] parameters of
+	// Redmine's advanced filters.
+	Params *Filter
 }
 
 type CustomField struct {
@@ -91,7 +150,11 @@ type CustomField struct {
 }
 
 func (c *Client) IssuesOf(projectId int) ([]Issue, error) {
-	issues, err := getIssues(c, "/issues.json?project_id="+strconv.Itoa(projectId)+"&key="+c.apikey+c.getPaginationClause())
+	return c.IssuesOfContext(context.Background(), projectId)
+}
+
+func (c *Client) IssuesOfContext(ctx context.Context, projectId int, opts ...ListOption) ([]Issue, error) {
+	issues, err := getIssues(ctx, c, NewFilter("project_id", strconv.Itoa(projectId)), opts)
 
 	if err != nil {
 		return nil, err
@@ -101,15 +164,46 @@ func (c *Client) IssuesOf(projectId int) ([]Issue, error) {
 }
 
 func (c *Client) Issue(id int) (*Issue, error) {
-	return getOneIssue(c, id, nil)
+	return c.IssueContext(context.Background(), id)
+}
+
+func (c *Client) IssueContext(ctx context.Context, id int) (*Issue, error) {
+	return getOneIssue(ctx, c, id, nil)
 }
 
 func (c *Client) IssueWithArgs(id int, args map[string]string) (*Issue, error) {
-	return getOneIssue(c, id, args)
+	return c.IssueWithArgsContext(context.Background(), id, args)
+}
+
+func (c *Client) IssueWithArgsContext(ctx context.Context, id int, args map[string]string) (*Issue, error) {
+	return getOneIssue(ctx, c, id, args)
+}
+
+// IssueWithInclude returns the issue with the given id along with the
+// associated data listed in include.
+func (c *Client) IssueWithInclude(id int, include ...IssueInclude) (*Issue, error) {
+	return c.IssueWithIncludeContext(context.Background(), id, include...)
+}
+
+// IssueWithIncludeContext is like IssueWithInclude but uses ctx for the
+// request.
+func (c *Client) IssueWithIncludeContext(ctx context.Context, id int, include ...IssueInclude) (*Issue, error) {
+	if len(include) == 0 {
+		return getOneIssue(ctx, c, id, nil)
+	}
+	names := make([]string, len(include))
+	for i, inc := range include {
+		names[i] = string(inc)
+	}
+	return getOneIssue(ctx, c, id, map[string]string{"include": strings.Join(names, ",")})
 }
 
 func (c *Client) IssuesByQuery(queryId int) ([]Issue, error) {
-	issues, err := getIssues(c, "/issues.json?query_id="+strconv.Itoa(queryId)+"&key="+c.apikey+c.getPaginationClause())
+	return c.IssuesByQueryContext(context.Background(), queryId)
+}
+
+func (c *Client) IssuesByQueryContext(ctx context.Context, queryId int, opts ...ListOption) ([]Issue, error) {
+	issues, err := getIssues(ctx, c, NewFilter("query_id", strconv.Itoa(queryId)), opts)
 
 	if err != nil {
 		return nil, err
@@ -119,7 +213,12 @@ func (c *Client) IssuesByQuery(queryId int) ([]Issue, error) {
 
 // IssuesByFilter filters issues applying the f criteria
 func (c *Client) IssuesByFilter(f *IssueFilter) ([]Issue, error) {
-	issues, err := getIssues(c, "/issues.json?key="+c.apikey+c.getPaginationClause()+getIssueFilterClause(f))
+	return c.IssuesByFilterContext(context.Background(), f)
+}
+
+// IssuesByFilterContext is like IssuesByFilter but uses ctx for the request.
+func (c *Client) IssuesByFilterContext(ctx context.Context, f *IssueFilter, opts ...ListOption) ([]Issue, error) {
+	issues, err := getIssues(ctx, c, getIssueFilter(f), opts)
 	if err != nil {
 		return nil, err
 	}
@@ -127,7 +226,11 @@ func (c *Client) IssuesByFilter(f *IssueFilter) ([]Issue, error) {
 }
 
 func (c *Client) Issues() ([]Issue, error) {
-	issues, err := getIssues(c, "/issues.json?key="+c.apikey+c.getPaginationClause())
+	return c.IssuesContext(context.Background())
+}
+
+func (c *Client) IssuesContext(ctx context.Context, opts ...ListOption) ([]Issue, error) {
+	issues, err := getIssues(ctx, c, nil, opts)
 
 	if err != nil {
 		return nil, err
@@ -136,19 +239,25 @@ func (c *Client) Issues() ([]Issue, error) {
 	return issues, nil
 }
 
+// CreateIssue creates issue. See CreateIssueWith to send only some
+// attributes.
 func (c *Client) CreateIssue(issue Issue) (*Issue, error) {
+	return c.CreateIssueContext(context.Background(), issue)
+}
+
+func (c *Client) CreateIssueContext(ctx context.Context, issue Issue) (*Issue, error) {
 	var ir issueRequest
 	ir.Issue = issue
 	s, err := json.Marshal(ir)
 	if err != nil {
 		return nil, err
 	}
-	req, err := http.NewRequest("POST", c.endpoint+"/issues.json?key="+c.apikey, strings.NewReader(string(s)))
+	req, err := http.NewRequestWithContext(ctx, "POST", c.endpoint+"/issues.json", strings.NewReader(string(s)))
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	res, err := c.Do(req)
+	res, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -157,11 +266,7 @@ func (c *Client) CreateIssue(issue Issue) (*Issue, error) {
 	decoder := json.NewDecoder(res.Body)
 	var r issueRequest
 	if res.StatusCode != 201 {
-		var er errorsResult
-		err = decoder.Decode(&er)
-		if err == nil {
-			err = errors.New(strings.Join(er.Errors, "\n"))
-		}
+		err = errorFromResp(res)
 	} else {
 		err = decoder.Decode(&r)
 	}
@@ -171,66 +276,56 @@ func (c *Client) CreateIssue(issue Issue) (*Issue, error) {
 	return &r.Issue, nil
 }
 
+// UpdateIssue sends all the attributes of issue, including zero values. See
+// UpdateIssueWith to change only some of them.
 func (c *Client) UpdateIssue(issue Issue) error {
+	return c.UpdateIssueContext(context.Background(), issue)
+}
+
+func (c *Client) UpdateIssueContext(ctx context.Context, issue Issue) error {
 	var ir issueRequest
 	ir.Issue = issue
 	s, err := json.Marshal(ir)
 	if err != nil {
 		return err
 	}
-	req, err := http.NewRequest("PUT", c.endpoint+"/issues/"+strconv.Itoa(issue.Id)+".json?key="+c.apikey, strings.NewReader(string(s)))
+	req, err := http.NewRequestWithContext(ctx, "PUT", c.endpoint+"/issues/"+strconv.Itoa(issue.Id)+".json", strings.NewReader(string(s)))
 	if err != nil {
 		return err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	res, err := c.Do(req)
+	res, err := c.do(req)
 	if err != nil {
 		return err
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode == 404 {
-		return errors.New("Not Found")
-	}
 	if res.StatusCode/100 != 2 {
-		decoder := json.NewDecoder(res.Body)
-		var er errorsResult
-		err = decoder.Decode(&er)
-		if err == nil {
-			err = errors.New(strings.Join(er.Errors, "\n"))
-		}
-	}
-	if err != nil {
-		return err
+		return errorFromResp(res)
 	}
-	return err
+	return nil
 }
 
 func (c *Client) DeleteIssue(id int) error {
-	req, err := http.NewRequest("DELETE", c.endpoint+"/issues/"+strconv.Itoa(id)+".json?key="+c.apikey, strings.NewReader(""))
+	return c.DeleteIssueContext(context.Background(), id)
+}
+
+func (c *Client) DeleteIssueContext(ctx context.Context, id int) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", c.endpoint+"/issues/"+strconv.Itoa(id)+".json", strings.NewReader(""))
 	if err != nil {
 		return err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	res, err := c.Do(req)
+	res, err := c.do(req)
 	if err != nil {
 		return err
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode == 404 {
-		return errors.New("Not Found")
-	}
-
-	decoder := json.NewDecoder(res.Body)
-	if res.StatusCode != 200 {
-		var er errorsResult
-		err = decoder.Decode(&er)
-		if err == nil {
-			err = errors.New(strings.Join(er.Errors, "\n"))
-		}
+	if res.StatusCode/100 != 2 {
+		return errorFromResp(res)
 	}
-	return err
+	return nil
 }
 
 func (issue *Issue) GetTitle() string {
@@ -263,76 +358,60 @@ func (issue Issue) MarshalJSON() ([]byte, error) {
 	})
 }
 
-func getIssueFilterClause(filter *IssueFilter) string {
+func getIssueFilter(filter *IssueFilter) *Filter {
+	f := NewFilter()
 	if filter == nil {
-		return ""
+		return f
 	}
-	clause := ""
 	if filter.ProjectId != "" {
-		clause = clause + fmt.Sprintf("&project_id=%v", filter.ProjectId)
+		f.AddPair("project_id", filter.ProjectId)
 	}
 	if filter.SubprojectId != "" {
-		clause = clause + fmt.Sprintf("&subproject_id=%v", filter.SubprojectId)
+		f.AddPair("subproject_id", filter.SubprojectId)
 	}
 	if filter.TrackerId != "" {
-		clause = clause + fmt.Sprintf("&tracker_id=%v", filter.TrackerId)
+		f.AddPair("tracker_id", filter.TrackerId)
 	}
 	if filter.StatusId != "" {
-		clause = clause + fmt.Sprintf("&status_id=%v", filter.StatusId)
+		f.AddPair("status_id", filter.StatusId)
 	}
 	if filter.AssignedToId != "" {
-		clause = clause + fmt.Sprintf("&assigned_to_id=%v", filter.AssignedToId)
+		f.AddPair("assigned_to_id", filter.AssignedToId)
 	}
 	if filter.UpdatedOn != "" {
-		clause = clause + fmt.Sprintf("&updated_on=%v", filter.UpdatedOn)
+		f.AddPair("updated_on", filter.UpdatedOn)
 	}
-
-	if filter.ExtraFilters != nil {
-		extraFilter := make([]string, 0)
-		for key, value := range filter.ExtraFilters {
-			extraFilter = append(extraFilter, fmt.Sprintf("%s=%s", key, value))
+	for key, value := range filter.ExtraFilters {
+		f.AddPair(key, value)
+	}
+	for key, values := range filter.Params.Values() {
+		for _, value := range values {
+			f.Add(key, value)
 		}
-		clause = clause + "&" + strings.Join(extraFilter[:], "&")
 	}
-
-	return clause
+	return f
 }
 
-func mapConcat(m map[string]string, delimiter string) string {
-	var args []string
-
-	for k, v := range m {
-		args = append(args, k+"="+v)
+func getOneIssue(ctx context.Context, c *Client, id int, args map[string]string) (*Issue, error) {
+	f := NewFilter()
+	for k, v := range args {
+		f.Set(k, v)
 	}
-
-	return strings.Join(args, delimiter)
-}
-
-func getOneIssue(c *Client, id int, args map[string]string) (*Issue, error) {
-	url := c.endpoint + "/issues/" + strconv.Itoa(id) + ".json?key=" + c.apikey
-
-	if args != nil {
-		url += "&" + mapConcat(args, "&")
+	url, err := c.url("/issues/"+strconv.Itoa(id)+".json", f)
+	if err != nil {
+		return nil, err
 	}
 
-	res, err := c.Get(url)
+	res, err := c.get(ctx, url)
 	if err != nil {
 		return nil, err
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode == 404 {
-		return nil, errors.New("Not Found")
-	}
-
 	decoder := json.NewDecoder(res.Body)
 	var r issueRequest
 	if res.StatusCode != 200 {
-		var er errorsResult
-		err = decoder.Decode(&er)
-		if err == nil {
-			err = errors.New(strings.Join(er.Errors, "\n"))
-		}
+		err = errorFromResp(res)
 	} else {
 		err = decoder.Decode(&r)
 	}
@@ -342,49 +421,102 @@ func getOneIssue(c *Client, id int, args map[string]string) (*Issue, error) {
 	return &r.Issue, nil
 }
 
-func getIssue(c *Client, url string, offset int) (*issuesResult, error) {
-	res, err := c.Get(c.endpoint + url + "&offset=" + strconv.Itoa(offset))
+func getIssues(ctx context.Context, c *Client, f *Filter, opts []ListOption) ([]Issue, error) {
+	if c.PageWorkers > 1 {
+		return getIssuesConcurrently(ctx, c, f, opts)
+	}
+	return getIssuesSequentially(ctx, c, f, opts)
+}
 
+func getIssuesSequentially(ctx context.Context, c *Client, f *Filter, opts []ListOption) ([]Issue, error) {
+	var issues []Issue
+	err := c.getPages(ctx, "/issues.json", f, opts, func(decoder *json.Decoder) (page, int, error) {
+		var r issuesResult
+		err := decoder.Decode(&r)
+		issues = append(issues, r.Issues...)
+		return r.page, len(r.Issues), err
+	})
 	if err != nil {
 		return nil, err
 	}
-	defer res.Body.Close()
+	return issues, nil
+}
 
-	decoder := json.NewDecoder(res.Body)
-	var r issuesResult
-	if res.StatusCode != 200 {
-		var er errorsResult
-		err = decoder.Decode(&er)
-		if err == nil {
-			err = errors.New(strings.Join(er.Errors, "\n"))
+// getIssuesConcurrently fetches the pages of issues with c.PageWorkers
+// concurrent requests. Unless another order is asked for, issues are
+// listed by id so that issues created in the meantime are appended at the
+// end instead of shifting the pages being fetched. Issues seen on two pages
+// are only returned once.
+//
+// Issues leaving the list in the meantime, e.g. closed ones under the
+// default open-only filter, shift the later pages and would be skipped.
+// When the total count changes between pages, or fewer issues than the
+// total count are found, the list is fetched again one page at a time.
+func getIssuesConcurrently(ctx context.Context, c *Client, f *Filter, opts []ListOption) ([]Issue, error) {
+	if f.Get("sort") == "" {
+		opts = append([]ListOption{Sort("id")}, opts...)
+	}
+	var (
+		mu    sync.Mutex
+		first page
+	)
+	pages := make(map[int][]Issue)
+	err := c.getPagesConcurrently(ctx, "/issues.json", f, opts, c.PageWorkers, func(i int, decoder *json.Decoder) (page, int, error) {
+		var r issuesResult
+		err := decoder.Decode(&r)
+		mu.Lock()
+		pages[i] = r.Issues
+		if i == 0 {
+			first = r.page
 		}
-	} else {
-		err = decoder.Decode(&r)
+		mu.Unlock()
+		return r.page, len(r.Issues), err
+	})
+	if err == errListChanged {
+		return getIssuesSequentially(ctx, c, f, opts)
 	}
 	if err != nil {
 		return nil, err
 	}
-
-	return &r, nil
-}
-
-func getIssues(c *Client, url string) ([]Issue, error) {
-	completed := false
 	var issues []Issue
-
-	for completed == false {
-		r, err := getIssue(c, url, len(issues))
-
-		if err != nil {
-			return nil, err
+	seen := make(map[int]bool)
+	for i := 0; i < len(pages); i++ {
+		for _, issue := range pages[i] {
+			if !seen[issue.Id] {
+				seen[issue.Id] = true
+				issues = append(issues, issue)
+			}
 		}
+	}
+	if len(issues) < first.TotalCount-first.Offset {
+		return getIssuesSequentially(ctx, c, f, opts)
+	}
+	return issues, nil
+}
 
-		if r.TotalCount == uint(len(issues)) {
-			completed = true
-		}
+// IssueIterator walks through issues page by page.
+type IssueIterator struct {
+	pager
+	issues []Issue
+}
 
-		issues = append(issues, r.Issues...)
-	}
+// IterIssues returns an iterator over the issues matching f.
+func (c *Client) IterIssues(ctx context.Context, f *IssueFilter, opts ...ListOption) *IssueIterator {
+	return &IssueIterator{pager: c.newPager(ctx, "/issues.json", getIssueFilter(f), opts)}
+}
 
-	return issues, nil
+// Next advances to the next issue. It returns false at the end of
+// the list or when an error occurred, see Err.
+func (it *IssueIterator) Next() bool {
+	return it.next(func(decoder *json.Decoder) (page, int, error) {
+		var r issuesResult
+		err := decoder.Decode(&r)
+		it.issues = r.Issues
+		return r.page, len(r.Issues), err
+	})
+}
+
+// Issue returns the current issue.
+func (it *IssueIterator) Issue() *Issue {
+	return &it.issues[it.pos]
 }
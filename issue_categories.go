@@ -1,8 +1,8 @@
 package redmine
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
 	"net/http"
 	"strconv"
 	"strings"
@@ -10,7 +10,7 @@ import (
 
 type issueCategoriesResult struct {
 	IssueCategories []IssueCategory `json:"issue_categories"`
-	TotalCount      int             `json:"total_count"`
+	page
 }
 
 type issueCategoryResult struct {
@@ -29,34 +29,29 @@ type IssueCategory struct {
 }
 
 func (c *Client) IssueCategories(projectId int) ([]IssueCategory, error) {
-	res, err := c.Get(c.endpoint + "/projects/" + strconv.Itoa(projectId) + "/issue_categories.json?key=" + c.apikey + c.getPaginationClause())
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
+	return c.IssueCategoriesContext(context.Background(), projectId)
+}
 
-	decoder := json.NewDecoder(res.Body)
-	var r issueCategoriesResult
-	if res.StatusCode == 404 {
-		return nil, errors.New("Not Found")
-	}
-	if res.StatusCode != 200 {
-		var er errorsResult
-		err = decoder.Decode(&er)
-		if err == nil {
-			err = errors.New(strings.Join(er.Errors, "\n"))
-		}
-	} else {
-		err = decoder.Decode(&r)
-	}
+func (c *Client) IssueCategoriesContext(ctx context.Context, projectId int, opts ...ListOption) ([]IssueCategory, error) {
+	var list []IssueCategory
+	err := c.getPages(ctx, "/projects/"+strconv.Itoa(projectId)+"/issue_categories.json", nil, opts, func(decoder *json.Decoder) (page, int, error) {
+		var r issueCategoriesResult
+		err := decoder.Decode(&r)
+		list = append(list, r.IssueCategories...)
+		return r.page, len(r.IssueCategories), err
+	})
 	if err != nil {
 		return nil, err
 	}
-	return r.IssueCategories, nil
+	return list, nil
 }
 
 func (c *Client) IssueCategory(id int) (*IssueCategory, error) {
-	res, err := c.Get(c.endpoint + "/issue_categories/" + strconv.Itoa(id) + ".json?key=" + c.apikey)
+	return c.IssueCategoryContext(context.Background(), id)
+}
+
+func (c *Client) IssueCategoryContext(ctx context.Context, id int) (*IssueCategory, error) {
+	res, err := c.get(ctx, c.endpoint+"/issue_categories/"+strconv.Itoa(id)+".json")
 	if err != nil {
 		return nil, err
 	}
@@ -64,15 +59,8 @@ func (c *Client) IssueCategory(id int) (*IssueCategory, error) {
 
 	decoder := json.NewDecoder(res.Body)
 	var r issueCategoryResult
-	if res.StatusCode == 404 {
-		return nil, errors.New("Not Found")
-	}
 	if res.StatusCode != 200 {
-		var er errorsResult
-		err = decoder.Decode(&er)
-		if err == nil {
-			err = errors.New(strings.Join(er.Errors, "\n"))
-		}
+		err = errorFromResp(res)
 	} else {
 		err = decoder.Decode(&r)
 	}
@@ -83,18 +71,22 @@ func (c *Client) IssueCategory(id int) (*IssueCategory, error) {
 }
 
 func (c *Client) CreateIssueCategory(issueCategory IssueCategory) (*IssueCategory, error) {
+	return c.CreateIssueCategoryContext(context.Background(), issueCategory)
+}
+
+func (c *Client) CreateIssueCategoryContext(ctx context.Context, issueCategory IssueCategory) (*IssueCategory, error) {
 	var ir issueCategoryRequest
 	ir.IssueCategory = issueCategory
 	s, err := json.Marshal(ir)
 	if err != nil {
 		return nil, err
 	}
-	req, err := http.NewRequest("POST", c.endpoint+"/issue_categories.json?key="+c.apikey, strings.NewReader(string(s)))
+	req, err := http.NewRequestWithContext(ctx, "POST", c.endpoint+"/issue_categories.json", strings.NewReader(string(s)))
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	res, err := c.Do(req)
+	res, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -103,11 +95,7 @@ func (c *Client) CreateIssueCategory(issueCategory IssueCategory) (*IssueCategor
 	decoder := json.NewDecoder(res.Body)
 	var r issueCategoryResult
 	if res.StatusCode != 201 {
-		var er errorsResult
-		err = decoder.Decode(&er)
-		if err == nil {
-			err = errors.New(strings.Join(er.Errors, "\n"))
-		}
+		err = errorFromResp(res)
 	} else {
 		err = decoder.Decode(&r)
 	}
@@ -118,63 +106,78 @@ func (c *Client) CreateIssueCategory(issueCategory IssueCategory) (*IssueCategor
 }
 
 func (c *Client) UpdateIssueCategory(issueCategory IssueCategory) error {
+	return c.UpdateIssueCategoryContext(context.Background(), issueCategory)
+}
+
+func (c *Client) UpdateIssueCategoryContext(ctx context.Context, issueCategory IssueCategory) error {
 	var ir issueCategoryRequest
 	ir.IssueCategory = issueCategory
 	s, err := json.Marshal(ir)
 	if err != nil {
 		return err
 	}
-	req, err := http.NewRequest("PUT", c.endpoint+"/issue_categories/"+strconv.Itoa(issueCategory.Id)+".json?key="+c.apikey, strings.NewReader(string(s)))
+	req, err := http.NewRequestWithContext(ctx, "PUT", c.endpoint+"/issue_categories/"+strconv.Itoa(issueCategory.Id)+".json", strings.NewReader(string(s)))
 	if err != nil {
 		return err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	res, err := c.Do(req)
+	res, err := c.do(req)
 	if err != nil {
 		return err
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode == 404 {
-		return errors.New("Not Found")
+	if res.StatusCode/100 != 2 {
+		return errorFromResp(res)
 	}
-	if res.StatusCode != 200 {
-		decoder := json.NewDecoder(res.Body)
-		var er errorsResult
-		err = decoder.Decode(&er)
-		if err == nil {
-			err = errors.New(strings.Join(er.Errors, "\n"))
-		}
-	}
-	if err != nil {
-		return err
-	}
-	return err
+	return nil
 }
 
 func (c *Client) DeleteIssueCategory(id int) error {
-	req, err := http.NewRequest("DELETE", c.endpoint+"/issue_categories/"+strconv.Itoa(id)+".json?key="+c.apikey, strings.NewReader(""))
+	return c.DeleteIssueCategoryContext(context.Background(), id)
+}
+
+func (c *Client) DeleteIssueCategoryContext(ctx context.Context, id int) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", c.endpoint+"/issue_categories/"+strconv.Itoa(id)+".json", strings.NewReader(""))
 	if err != nil {
 		return err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	res, err := c.Do(req)
+	res, err := c.do(req)
 	if err != nil {
 		return err
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode == 404 {
-		return errors.New("Not Found")
+	if res.StatusCode/100 != 2 {
+		return errorFromResp(res)
 	}
+	return nil
+}
 
-	decoder := json.NewDecoder(res.Body)
-	if res.StatusCode != 200 {
-		var er errorsResult
-		err = decoder.Decode(&er)
-		if err == nil {
-			err = errors.New(strings.Join(er.Errors, "\n"))
-		}
-	}
-	return err
+// IssueCategoryIterator walks through issue categories page by page.
+type IssueCategoryIterator struct {
+	pager
+	categories []IssueCategory
+}
+
+// IterIssueCategories returns an iterator over the issue categories of the given project.
+func (c *Client) IterIssueCategories(ctx context.Context, projectId int, opts ...ListOption) *IssueCategoryIterator {
+	return &IssueCategoryIterator{pager: c.newPager(ctx, "/projects/"+strconv.Itoa(projectId)+"/issue_categories.json", nil, opts)}
+}
+
+// Next advances to the next issue category. It returns false at the end of
+// the list or when an error occurred, see Err.
+func (it *IssueCategoryIterator) Next() bool {
+	return it.next(func(decoder *json.Decoder) (page, int, error) {
+		var r issueCategoriesResult
+		err := decoder.Decode(&r)
+		it.categories = r.IssueCategories
+		return r.page, len(r.IssueCategories), err
+	})
+}
+
+// IssueCategory returns the current issue category.
+func (it *IssueCategoryIterator) IssueCategory() *IssueCategory {
+	return &it.categories[it.pos]
 }
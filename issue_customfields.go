@@ -1,11 +1,8 @@
 package redmine
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
-	"fmt"
-	"net/http"
-	"strings"
 )
 
 type customFieldsResult struct {
@@ -14,20 +11,12 @@ type customFieldsResult struct {
 
 // CustomFields consulta los campos personalizados
 func (c *Client) CustomFields() ([]CustomField, error) {
-	req, err := http.NewRequest(
-		"GET",
-		fmt.Sprintf("%s/custom_fields.json?%s",
-			c.endpoint,
-			c.getPaginationClause()),
-		nil)
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("X-Redmine-API-Key", c.apikey)
+	return c.CustomFieldsContext(context.Background())
+}
 
-	res, err := c.Do(req)
+// CustomFieldsContext is like CustomFields but uses ctx for the request.
+func (c *Client) CustomFieldsContext(ctx context.Context) ([]CustomField, error) {
+	res, err := c.get(ctx, c.endpoint+"/custom_fields.json")
 	if err != nil {
 		return nil, err
 	}
@@ -36,11 +25,7 @@ func (c *Client) CustomFields() ([]CustomField, error) {
 	decoder := json.NewDecoder(res.Body)
 	var r customFieldsResult
 	if res.StatusCode != 200 {
-		var er errorsResult
-		err = decoder.Decode(&er)
-		if err == nil {
-			err = errors.New(strings.Join(er.Errors, "\n"))
-		}
+		err = errorFromResp(res)
 	} else {
 		err = decoder.Decode(&r)
 	}
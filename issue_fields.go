@@ -0,0 +1,255 @@
+package redmine
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// IssueFields holds the attributes sent by CreateIssueWith and
+// UpdateIssueWith. Attributes which are not set are not sent, so an update
+// only changes what was set, and Clear empties an attribute explicitly:
+//
+//	f := redmine.NewIssueFields().
+//		Status(3).
+//		Notes("Fixed in r1234").
+//		Clear("assigned_to_id")
+//	err := c.UpdateIssueWith(42, f)
+type IssueFields struct {
+	attributes
+}
+
+type issueFieldsRequest struct {
+	Issue *IssueFields `json:"issue"`
+}
+
+// NewIssueFields returns an empty set of attributes.
+func NewIssueFields() *IssueFields {
+	return &IssueFields{}
+}
+
+// Set sets the attribute name, e.g. "subject" or "fixed_version_id", to
+// value.
+func (f *IssueFields) Set(name string, value interface{}) *IssueFields {
+	f.set(name, value)
+	return f
+}
+
+// Clear empties the attribute name, e.g. "assigned_to_id" or "due_date".
+func (f *IssueFields) Clear(name string) *IssueFields {
+	return f.Set(name, "")
+}
+
+// Unset removes the attribute name, which is then left unchanged.
+func (f *IssueFields) Unset(name string) *IssueFields {
+	f.unset(name)
+	return f
+}
+
+// Has reports whether the attribute name is set or cleared.
+func (f *IssueFields) Has(name string) bool {
+	return f.has(name)
+}
+
+func (f *IssueFields) Project(id int) *IssueFields       { return f.Set("project_id", id) }
+func (f *IssueFields) Tracker(id int) *IssueFields       { return f.Set("tracker_id", id) }
+func (f *IssueFields) Status(id int) *IssueFields        { return f.Set("status_id", id) }
+func (f *IssueFields) Priority(id int) *IssueFields      { return f.Set("priority_id", id) }
+func (f *IssueFields) Category(id int) *IssueFields      { return f.Set("category_id", id) }
+func (f *IssueFields) FixedVersion(id int) *IssueFields  { return f.Set("fixed_version_id", id) }
+func (f *IssueFields) AssignedTo(id int) *IssueFields    { return f.Set("assigned_to_id", id) }
+func (f *IssueFields) Parent(id int) *IssueFields        { return f.Set("parent_issue_id", id) }
+func (f *IssueFields) Subject(s string) *IssueFields     { return f.Set("subject", s) }
+func (f *IssueFields) Description(s string) *IssueFields { return f.Set("description", s) }
+func (f *IssueFields) StartDate(d Date) *IssueFields     { return f.Set("start_date", d) }
+func (f *IssueFields) DueDate(d Date) *IssueFields       { return f.Set("due_date", d) }
+func (f *IssueFields) DoneRatio(n int) *IssueFields      { return f.Set("done_ratio", n) }
+func (f *IssueFields) IsPrivate(b bool) *IssueFields     { return f.Set("is_private", b) }
+func (f *IssueFields) EstimatedHours(h float64) *IssueFields {
+	return f.Set("estimated_hours", h)
+}
+
+// Notes adds a note to the journal of an update.
+func (f *IssueFields) Notes(s string) *IssueFields {
+	return f.Set("notes", s)
+}
+
+// PrivateNotes makes the notes of an update private.
+func (f *IssueFields) PrivateNotes(b bool) *IssueFields {
+	return f.Set("private_notes", b)
+}
+
+// Uploads attaches files uploaded with Upload.
+func (f *IssueFields) Uploads(uploads ...*Upload) *IssueFields {
+	return f.Set("uploads", uploads)
+}
+
+// Watchers makes the users with the given ids watch a new issue. Use
+// AddIssueWatcher for existing issues.
+func (f *IssueFields) Watchers(userIds ...int) *IssueFields {
+	return f.Set("watcher_user_ids", userIds)
+}
+
+// CustomField sets the value of the custom field with the given id. Give a
+// []string for custom fields accepting several values, and "" to clear it.
+func (f *IssueFields) CustomField(id int, value interface{}) *IssueFields {
+	f.setCustomField(id, value)
+	return f
+}
+
+// CreateIssueWith creates an issue with the attributes set in f.
+func (c *Client) CreateIssueWith(f *IssueFields) (*Issue, error) {
+	return c.CreateIssueWithContext(context.Background(), f)
+}
+
+// CreateIssueWithContext is like CreateIssueWith but uses ctx for the request.
+func (c *Client) CreateIssueWithContext(ctx context.Context, f *IssueFields) (*Issue, error) {
+	s, err := json.Marshal(issueFieldsRequest{f})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", c.endpoint+"/issues.json", strings.NewReader(string(s)))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	decoder := json.NewDecoder(res.Body)
+	var r issueResult
+	if res.StatusCode != 201 {
+		err = errorFromResp(res)
+	} else {
+		err = decoder.Decode(&r)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &r.Issue, nil
+}
+
+// UpdateIssueWith changes the attributes set in f of the issue with the
+// given id, leaving the others unchanged.
+func (c *Client) UpdateIssueWith(id int, f *IssueFields) error {
+	return c.UpdateIssueWithContext(context.Background(), id, f)
+}
+
+// UpdateIssueWithContext is like UpdateIssueWith but uses ctx for the request.
+func (c *Client) UpdateIssueWithContext(ctx context.Context, id int, f *IssueFields) error {
+	s, err := json.Marshal(issueFieldsRequest{f})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, "PUT", c.endpoint+"/issues/"+strconv.Itoa(id)+".json", strings.NewReader(string(s)))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode/100 != 2 {
+		return errorFromResp(res)
+	}
+	return nil
+}
+
+// UpdateIssueIfUnmodified is like UpdateIssueWith but first reads the issue
+// again and returns a *ConflictError if it was updated at another time than
+// updatedOn, which is usually the UpdatedOn of the issue as it was read.
+//
+// The check and the update are two requests, so a change made in between
+// is not detected.
+func (c *Client) UpdateIssueIfUnmodified(id int, updatedOn DateTime, f *IssueFields) error {
+	return c.UpdateIssueIfUnmodifiedContext(context.Background(), id, updatedOn, f)
+}
+
+// UpdateIssueIfUnmodifiedContext is like UpdateIssueIfUnmodified but uses ctx
+// for the requests.
+func (c *Client) UpdateIssueIfUnmodifiedContext(ctx context.Context, id int, updatedOn DateTime, f *IssueFields) error {
+	return c.updateIssueGuarded(ctx, id, updatedOn, f, false)
+}
+
+// UpdateIssueMerging is like UpdateIssueIfUnmodified but only returns a
+// *ConflictError if the changes made since updatedOn touch attributes set in
+// f. Changes to other attributes and notes are kept.
+func (c *Client) UpdateIssueMerging(id int, updatedOn DateTime, f *IssueFields) error {
+	return c.UpdateIssueMergingContext(context.Background(), id, updatedOn, f)
+}
+
+// UpdateIssueMergingContext is like UpdateIssueMerging but uses ctx for the
+// requests.
+func (c *Client) UpdateIssueMergingContext(ctx context.Context, id int, updatedOn DateTime, f *IssueFields) error {
+	return c.updateIssueGuarded(ctx, id, updatedOn, f, true)
+}
+
+func (c *Client) updateIssueGuarded(ctx context.Context, id int, updatedOn DateTime, f *IssueFields, merge bool) error {
+	issue, err := getOneIssue(ctx, c, id, map[string]string{"include": "journals"})
+	if err != nil {
+		return err
+	}
+	if !issue.UpdatedOn.Equal(updatedOn.Time) {
+		conflict := &ConflictError{IssueId: id, UpdatedOn: issue.UpdatedOn}
+		for _, j := range issue.Journals {
+			if j.CreatedOn.After(updatedOn.Time) {
+				conflict.Journals = append(conflict.Journals, j)
+			}
+		}
+		if !merge {
+			return conflict
+		}
+		conflict.Fields = f.changedIn(conflict.Journals)
+		if len(conflict.Fields) > 0 {
+			return conflict
+		}
+	}
+	return c.UpdateIssueWithContext(ctx, id, f)
+}
+
+// journalAttrs maps the attribute names recorded in journals to the names
+// of the attributes sent on update, where they differ.
+var journalAttrs = map[string]string{
+	"parent_id": "parent_issue_id",
+}
+
+// changedIn returns the attributes set in f which are changed by journals.
+// Custom fields are named "cf_<id>".
+func (f *IssueFields) changedIn(journals []*Journal) []string {
+	var changed []string
+	seen := make(map[string]bool)
+	for _, j := range journals {
+		for _, d := range j.Details {
+			var name string
+			switch d.Property {
+			case "attr":
+				attr := d.Name
+				if a, ok := journalAttrs[attr]; ok {
+					attr = a
+				}
+				if f.Has(attr) {
+					name = attr
+				}
+			case "cf":
+				for _, cf := range f.customFields {
+					if strconv.Itoa(cf.Id) == d.Name {
+						name = "cf_" + d.Name
+					}
+				}
+			}
+			if name != "" && !seen[name] {
+				seen[name] = true
+				changed = append(changed, name)
+			}
+		}
+	}
+	return changed
+}
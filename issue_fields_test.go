@@ -0,0 +1,109 @@
+package redmine
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestIssueFieldsChangedIn(t *testing.T) {
+	journals := []*Journal{
+		{Details: []JournalDetails{
+			{Property: "attr", Name: "status_id"},
+			{Property: "attr", Name: "subject"},
+		}},
+		{Details: []JournalDetails{
+			{Property: "cf", Name: "7"},
+			{Property: "attr", Name: "parent_id"},
+			{Property: "attr", Name: "status_id"},
+		}},
+		{Notes: "no details"},
+	}
+	tests := []struct {
+		f    *IssueFields
+		want []string
+	}{
+		{NewIssueFields().Status(2), []string{"status_id"}},
+		{NewIssueFields().CustomField(7, "x"), []string{"cf_7"}},
+		{NewIssueFields().CustomField(8, "x"), nil},
+		{NewIssueFields().Parent(3), []string{"parent_issue_id"}},
+		{NewIssueFields().Clear("parent_issue_id"), []string{"parent_issue_id"}},
+		{NewIssueFields().Description("x").Notes("y"), nil},
+		{NewIssueFields().Subject("x").Status(2), []string{"status_id", "subject"}},
+	}
+	for _, tt := range tests {
+		if got := tt.f.changedIn(journals); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%v: got %v, want %v", tt.f.fields, got, tt.want)
+		}
+	}
+}
+
+func TestUpdateIssueGuarded(t *testing.T) {
+	var puts []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			if r.URL.Query().Get("include") != "journals" {
+				t.Errorf("include = %q, want journals", r.URL.Query().Get("include"))
+			}
+			io.WriteString(w, `{"issue":{"id":1,"updated_on":"2024-01-02T00:00:00Z","journals":[
+				{"id":1,"created_on":"2024-01-01T00:00:00Z","details":[{"property":"attr","name":"subject"}]},
+				{"id":2,"created_on":"2024-01-02T00:00:00Z","details":[{"property":"attr","name":"parent_id"}]}]}}`)
+			return
+		}
+		b, _ := ioutil.ReadAll(r.Body)
+		puts = append(puts, string(b))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+	c := NewClient(srv.URL, "key")
+	current := DateTime{time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)}
+	stale := DateTime{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+	tests := []struct {
+		name      string
+		merge     bool
+		updatedOn DateTime
+		f         *IssueFields
+		conflict  []string // fields of the expected conflict, nil for none
+		journals  int
+	}{
+		{"unmodified", false, current, NewIssueFields().Subject("x"), nil, 0},
+		{"modified", false, stale, NewIssueFields().Subject("x"), []string{}, 1},
+		{"merged", true, stale, NewIssueFields().Subject("x"), nil, 0},
+		{"reparented", true, stale, NewIssueFields().Parent(3), []string{"parent_issue_id"}, 1},
+	}
+	for _, tt := range tests {
+		puts = nil
+		var err error
+		if tt.merge {
+			err = c.UpdateIssueMerging(1, tt.updatedOn, tt.f)
+		} else {
+			err = c.UpdateIssueIfUnmodified(1, tt.updatedOn, tt.f)
+		}
+		if tt.conflict == nil {
+			if err != nil || len(puts) != 1 {
+				t.Errorf("%s: err = %v, %d updates, want the update sent", tt.name, err, len(puts))
+			}
+			continue
+		}
+		var ce *ConflictError
+		if !errors.As(err, &ce) || !errors.Is(err, ErrConflict) {
+			t.Errorf("%s: err = %v, want a *ConflictError", tt.name, err)
+			continue
+		}
+		if len(puts) != 0 {
+			t.Errorf("%s: update sent despite the conflict", tt.name)
+		}
+		if len(ce.Journals) != tt.journals || ce.Journals[0].Id != 2 {
+			t.Errorf("%s: journals %v, want only the one after updatedOn", tt.name, ce.Journals)
+		}
+		if len(tt.conflict) > 0 && !reflect.DeepEqual(ce.Fields, tt.conflict) {
+			t.Errorf("%s: fields %v, want %v", tt.name, ce.Fields, tt.conflict)
+		}
+	}
+}
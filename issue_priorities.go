@@ -1,9 +1,8 @@
 package redmine
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
-	"strings"
 )
 
 type issuePrioritiesResult struct {
@@ -17,7 +16,11 @@ type IssuePriority struct {
 }
 
 func (c *Client) IssuePriorities() ([]IssuePriority, error) {
-	res, err := c.Get(c.endpoint + "/enumerations/issue_priorities.json?key=" + c.apikey + c.getPaginationClause())
+	return c.IssuePrioritiesContext(context.Background())
+}
+
+func (c *Client) IssuePrioritiesContext(ctx context.Context) ([]IssuePriority, error) {
+	res, err := c.get(ctx, c.endpoint+"/enumerations/issue_priorities.json")
 	if err != nil {
 		return nil, err
 	}
@@ -26,11 +29,7 @@ func (c *Client) IssuePriorities() ([]IssuePriority, error) {
 	decoder := json.NewDecoder(res.Body)
 	var r issuePrioritiesResult
 	if res.StatusCode != 200 {
-		var er errorsResult
-		err = decoder.Decode(&er)
-		if err == nil {
-			err = errors.New(strings.Join(er.Errors, "\n"))
-		}
+		err = errorFromResp(res)
 	} else {
 		err = decoder.Decode(&r)
 	}
@@ -0,0 +1,121 @@
+package redmine
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Operator is an operator of Redmine's advanced issue filters.
+type Operator string
+
+const (
+	OpEquals         Operator = "="
+	OpNotEquals      Operator = "!"
+	OpContains       Operator = "~"
+	OpNotContains    Operator = "!~"
+	OpStartsWith     Operator = "^"
+	OpEndsWith       Operator = "$"
+	OpGreaterOrEqual Operator = ">="
+	OpLessOrEqual    Operator = "<="
+	OpBetween        Operator = "><"
+	OpAny            Operator = "*"  // the field is set
+	OpNone           Operator = "!*" // the field is not set
+	OpOpen           Operator = "o"  // status_id only
+	OpClosed         Operator = "c"  // status_id only
+
+	// Relative dates, the value is a number of days.
+	OpLessThanDaysAgo Operator = ">t-"
+	OpMoreThanDaysAgo Operator = "<t-"
+	OpDaysAgo         Operator = "t-"
+	OpInLessThanDays  Operator = "<t+"
+	OpInMoreThanDays  Operator = ">t+"
+	OpInDays          Operator = "t+"
+
+	// Relative periods, they take no value.
+	OpToday     Operator = "t"
+	OpYesterday Operator = "ld"
+	OpThisWeek  Operator = "w"
+	OpLastWeek  Operator = "lw"
+	OpThisMonth Operator = "m"
+	OpLastMonth Operator = "lm"
+	OpThisYear  Operator = "y"
+)
+
+// IssueQuery builds the parameters of Redmine's advanced issue filters:
+//
+//	q := redmine.NewIssueQuery().
+//		Where("status_id", redmine.OpOpen).
+//		Where("tracker_id", redmine.OpEquals, "1", "2").
+//		Where("updated_on", redmine.OpLessThanDaysAgo, "7").
+//		CustomField(12, redmine.OpContains, "backend").
+//		OrderBy("priority", true)
+//	issues, err := c.IssuesByFilter(q.IssueFilter())
+//
+// Without any condition on status_id Redmine only lists open issues, use
+// Where("status_id", OpAny) to list all of them.
+type IssueQuery struct {
+	filter Filter
+	sort   []string
+}
+
+// NewIssueQuery returns an empty query.
+func NewIssueQuery() *IssueQuery {
+	q := &IssueQuery{}
+	q.filter.Set("set_filter", "1")
+	return q
+}
+
+// Where adds a condition on field, replacing any previous condition on it.
+func (q *IssueQuery) Where(field string, op Operator, values ...string) *IssueQuery {
+	if q.filter.Get("op["+field+"]") == "" {
+		q.filter.Add("f[]", field)
+	}
+	q.filter.Set("op["+field+"]", string(op))
+	q.filter.Del("v[" + field + "][]")
+	for _, v := range values {
+		q.filter.Add("v["+field+"][]", v)
+	}
+	return q
+}
+
+// Between adds a condition matching values of field between from and to,
+// both included.
+func (q *IssueQuery) Between(field string, from, to string) *IssueQuery {
+	return q.Where(field, OpBetween, from, to)
+}
+
+// CustomField adds a condition on the custom field with the given id.
+func (q *IssueQuery) CustomField(id int, op Operator, values ...string) *IssueQuery {
+	return q.Where("cf_"+strconv.Itoa(id), op, values...)
+}
+
+// OrderBy sorts the issues by field, after the fields given before.
+func (q *IssueQuery) OrderBy(field string, desc bool) *IssueQuery {
+	if desc {
+		field += ":desc"
+	}
+	q.sort = append(q.sort, field)
+	return q
+}
+
+// GroupBy groups the issues by field. Grouped issues are listed one group
+// after the other.
+func (q *IssueQuery) GroupBy(field string) *IssueQuery {
+	q.filter.Set("group_by", field)
+	return q
+}
+
+// Filter returns the query parameters of q.
+func (q *IssueQuery) Filter() *Filter {
+	f := q.filter.clone()
+	if len(q.sort) > 0 {
+		f.Set("sort", strings.Join(q.sort, ","))
+	}
+	return f
+}
+
+// IssueFilter returns an IssueFilter for q, to be given to IssuesByFilter
+// or IterIssues.
+func (q *IssueQuery) IssueFilter() *IssueFilter {
+	return &IssueFilter{Params: q.Filter()}
+}
@@ -1,8 +1,8 @@
 package redmine
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
 	"net/http"
 	"strconv"
 	"strings"
@@ -13,23 +13,43 @@ type issueRelationsResult struct {
 }
 
 type issueRelationResult struct {
-	IssueRelation IssueRelation `json:"issue_relation"`
+	IssueRelation IssueRelation `json:"relation"`
 }
 
 type issueRelationRequest struct {
-	IssueRelation IssueRelation `json:"issue_relation"`
+	IssueRelation issueRelationFields `json:"relation"`
+}
+
+// issueRelationFields are the attributes of a relation Redmine accepts,
+// the issue itself is given by the URL.
+type issueRelationFields struct {
+	IssueToId    int    `json:"issue_to_id"`
+	RelationType string `json:"relation_type"`
+	Delay        *int   `json:"delay,omitempty"`
+}
+
+func newIssueRelationRequest(r IssueRelation) issueRelationRequest {
+	return issueRelationRequest{issueRelationFields{
+		IssueToId:    r.IssueToId,
+		RelationType: r.RelationType,
+		Delay:        r.Delay,
+	}}
 }
 
 type IssueRelation struct {
 	Id           int    `json:"id"`
-	IssueId      string `json:"issue_id"`
-	IssueToId    string `json:"issue_to_id"`
+	IssueId      int    `json:"issue_id"`
+	IssueToId    int    `json:"issue_to_id"`
 	RelationType string `json:"relation_type"`
-	Delay        string `json:"delay"`
+	Delay        *int   `json:"delay,omitempty"` // days, for precedes and follows only
 }
 
 func (c *Client) IssueRelations(issueId int) ([]IssueRelation, error) {
-	res, err := c.Get(c.endpoint + "/issue/" + strconv.Itoa(issueId) + "/relations.json?key=" + c.apikey + c.getPaginationClause())
+	return c.IssueRelationsContext(context.Background(), issueId)
+}
+
+func (c *Client) IssueRelationsContext(ctx context.Context, issueId int) ([]IssueRelation, error) {
+	res, err := c.get(ctx, c.endpoint+"/issues/"+strconv.Itoa(issueId)+"/relations.json")
 	if err != nil {
 		return nil, err
 	}
@@ -37,15 +57,8 @@ func (c *Client) IssueRelations(issueId int) ([]IssueRelation, error) {
 
 	decoder := json.NewDecoder(res.Body)
 	var r issueRelationsResult
-	if res.StatusCode == 404 {
-		return nil, errors.New("Not Found")
-	}
 	if res.StatusCode != 200 {
-		var er errorsResult
-		err = decoder.Decode(&er)
-		if err == nil {
-			err = errors.New(strings.Join(er.Errors, "\n"))
-		}
+		err = errorFromResp(res)
 	} else {
 		err = decoder.Decode(&r)
 	}
@@ -56,7 +69,11 @@ func (c *Client) IssueRelations(issueId int) ([]IssueRelation, error) {
 }
 
 func (c *Client) IssueRelation(id int) (*IssueRelation, error) {
-	res, err := c.Get(c.endpoint + "/relations/" + strconv.Itoa(id) + ".json?key=" + c.apikey)
+	return c.IssueRelationContext(context.Background(), id)
+}
+
+func (c *Client) IssueRelationContext(ctx context.Context, id int) (*IssueRelation, error) {
+	res, err := c.get(ctx, c.endpoint+"/relations/"+strconv.Itoa(id)+".json")
 	if err != nil {
 		return nil, err
 	}
@@ -64,15 +81,8 @@ func (c *Client) IssueRelation(id int) (*IssueRelation, error) {
 
 	decoder := json.NewDecoder(res.Body)
 	var r issueRelationResult
-	if res.StatusCode == 404 {
-		return nil, errors.New("Not Found")
-	}
 	if res.StatusCode != 200 {
-		var er errorsResult
-		err = decoder.Decode(&er)
-		if err == nil {
-			err = errors.New(strings.Join(er.Errors, "\n"))
-		}
+		err = errorFromResp(res)
 	} else {
 		err = decoder.Decode(&r)
 	}
@@ -82,19 +92,22 @@ func (c *Client) IssueRelation(id int) (*IssueRelation, error) {
 	return &r.IssueRelation, nil
 }
 
+// CreateIssueRelation relates issueRelation.IssueId to issueRelation.IssueToId.
 func (c *Client) CreateIssueRelation(issueRelation IssueRelation) (*IssueRelation, error) {
-	var ir issueRelationRequest
-	ir.IssueRelation = issueRelation
-	s, err := json.Marshal(ir)
+	return c.CreateIssueRelationContext(context.Background(), issueRelation)
+}
+
+func (c *Client) CreateIssueRelationContext(ctx context.Context, issueRelation IssueRelation) (*IssueRelation, error) {
+	s, err := json.Marshal(newIssueRelationRequest(issueRelation))
 	if err != nil {
 		return nil, err
 	}
-	req, err := http.NewRequest("POST", c.endpoint+"/relations.json?key="+c.apikey, strings.NewReader(string(s)))
+	req, err := http.NewRequestWithContext(ctx, "POST", c.endpoint+"/issues/"+strconv.Itoa(issueRelation.IssueId)+"/relations.json", strings.NewReader(string(s)))
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	res, err := c.Do(req)
+	res, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -103,11 +116,7 @@ func (c *Client) CreateIssueRelation(issueRelation IssueRelation) (*IssueRelatio
 	decoder := json.NewDecoder(res.Body)
 	var r issueRelationResult
 	if res.StatusCode != 201 {
-		var er errorsResult
-		err = decoder.Decode(&er)
-		if err == nil {
-			err = errors.New(strings.Join(er.Errors, "\n"))
-		}
+		err = errorFromResp(res)
 	} else {
 		err = decoder.Decode(&r)
 	}
@@ -118,63 +127,49 @@ func (c *Client) CreateIssueRelation(issueRelation IssueRelation) (*IssueRelatio
 }
 
 func (c *Client) UpdateIssueRelation(issueRelation IssueRelation) error {
-	var ir issueRelationRequest
-	ir.IssueRelation = issueRelation
-	s, err := json.Marshal(ir)
+	return c.UpdateIssueRelationContext(context.Background(), issueRelation)
+}
+
+func (c *Client) UpdateIssueRelationContext(ctx context.Context, issueRelation IssueRelation) error {
+	s, err := json.Marshal(newIssueRelationRequest(issueRelation))
 	if err != nil {
 		return err
 	}
-	req, err := http.NewRequest("PUT", c.endpoint+"/relations/"+strconv.Itoa(issueRelation.Id)+".json?key="+c.apikey, strings.NewReader(string(s)))
+	req, err := http.NewRequestWithContext(ctx, "PUT", c.endpoint+"/relations/"+strconv.Itoa(issueRelation.Id)+".json", strings.NewReader(string(s)))
 	if err != nil {
 		return err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	res, err := c.Do(req)
+	res, err := c.do(req)
 	if err != nil {
 		return err
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode == 404 {
-		return errors.New("Not Found")
-	}
-	if res.StatusCode != 200 {
-		decoder := json.NewDecoder(res.Body)
-		var er errorsResult
-		err = decoder.Decode(&er)
-		if err == nil {
-			err = errors.New(strings.Join(er.Errors, "\n"))
-		}
-	}
-	if err != nil {
-		return err
+	if res.StatusCode/100 != 2 {
+		return errorFromResp(res)
 	}
-	return err
+	return nil
 }
 
 func (c *Client) DeleteIssueRelation(id int) error {
-	req, err := http.NewRequest("DELETE", c.endpoint+"/relations/"+strconv.Itoa(id)+".json?key="+c.apikey, strings.NewReader(""))
+	return c.DeleteIssueRelationContext(context.Background(), id)
+}
+
+func (c *Client) DeleteIssueRelationContext(ctx context.Context, id int) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", c.endpoint+"/relations/"+strconv.Itoa(id)+".json", strings.NewReader(""))
 	if err != nil {
 		return err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	res, err := c.Do(req)
+	res, err := c.do(req)
 	if err != nil {
 		return err
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode == 404 {
-		return errors.New("Not Found")
-	}
-
-	decoder := json.NewDecoder(res.Body)
-	if res.StatusCode != 200 {
-		var er errorsResult
-		err = decoder.Decode(&er)
-		if err == nil {
-			err = errors.New(strings.Join(er.Errors, "\n"))
-		}
+	if res.StatusCode/100 != 2 {
+		return errorFromResp(res)
 	}
-	return err
+	return nil
 }
@@ -0,0 +1,45 @@
+package redmine
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestIssueRelation(t *testing.T) {
+	const reply = `{"relation":{"id":5,"issue_id":1,"issue_to_id":2,"relation_type":"precedes","delay":3}}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "POST /issues/1/relations.json":
+			b, _ := ioutil.ReadAll(r.Body)
+			if want := `{"relation":{"issue_to_id":2,"relation_type":"precedes","delay":3}}`; string(b) != want {
+				t.Errorf("sent %s, want %s", b, want)
+			}
+			w.WriteHeader(http.StatusCreated)
+			io.WriteString(w, reply)
+		case "GET /relations/5.json":
+			io.WriteString(w, reply)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	c := NewClient(srv.URL, "key")
+
+	delay := 3
+	created, err := c.CreateIssueRelation(IssueRelation{IssueId: 1, IssueToId: 2, RelationType: "precedes", Delay: &delay})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := c.IssueRelation(5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range []*IssueRelation{created, got} {
+		if r.Id != 5 || r.IssueId != 1 || r.IssueToId != 2 || r.RelationType != "precedes" || r.Delay == nil || *r.Delay != 3 {
+			t.Errorf("decoded %+v", r)
+		}
+	}
+}
@@ -1,9 +1,8 @@
 package redmine
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
-	"strings"
 )
 
 type issueStatusesResult struct {
@@ -18,7 +17,11 @@ type IssueStatus struct {
 }
 
 func (c *Client) IssueStatuses() ([]IssueStatus, error) {
-	res, err := c.Get(c.endpoint + "/issue_statuses.json?key=" + c.apikey + c.getPaginationClause())
+	return c.IssueStatusesContext(context.Background())
+}
+
+func (c *Client) IssueStatusesContext(ctx context.Context) ([]IssueStatus, error) {
+	res, err := c.get(ctx, c.endpoint+"/issue_statuses.json")
 	if err != nil {
 		return nil, err
 	}
@@ -27,11 +30,7 @@ func (c *Client) IssueStatuses() ([]IssueStatus, error) {
 	decoder := json.NewDecoder(res.Body)
 	var r issueStatusesResult
 	if res.StatusCode != 200 {
-		var er errorsResult
-		err = decoder.Decode(&er)
-		if err == nil {
-			err = errors.New(strings.Join(er.Errors, "\n"))
-		}
+		err = errorFromResp(res)
 	} else {
 		err = decoder.Decode(&r)
 	}
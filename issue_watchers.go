@@ -0,0 +1,80 @@
+package redmine
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type userIdRequest struct {
+	UserId int `json:"user_id"`
+}
+
+// IssueWatchers returns the users watching the issue with the given id.
+func (c *Client) IssueWatchers(issueId int) ([]*IdName, error) {
+	return c.IssueWatchersContext(context.Background(), issueId)
+}
+
+// IssueWatchersContext is like IssueWatchers but uses ctx for the request.
+func (c *Client) IssueWatchersContext(ctx context.Context, issueId int) ([]*IdName, error) {
+	issue, err := c.IssueWithIncludeContext(ctx, issueId, IncludeWatchers)
+	if err != nil {
+		return nil, err
+	}
+	return issue.Watchers, nil
+}
+
+// AddIssueWatcher makes the user with the given id watch the issue.
+func (c *Client) AddIssueWatcher(issueId, userId int) error {
+	return c.AddIssueWatcherContext(context.Background(), issueId, userId)
+}
+
+// AddIssueWatcherContext is like AddIssueWatcher but uses ctx for the request.
+func (c *Client) AddIssueWatcherContext(ctx context.Context, issueId, userId int) error {
+	s, err := json.Marshal(userIdRequest{UserId: userId})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", c.endpoint+"/issues/"+strconv.Itoa(issueId)+"/watchers.json", strings.NewReader(string(s)))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode/100 != 2 {
+		return errorFromResp(res)
+	}
+	return nil
+}
+
+// RemoveIssueWatcher stops the user with the given id watching the issue.
+func (c *Client) RemoveIssueWatcher(issueId, userId int) error {
+	return c.RemoveIssueWatcherContext(context.Background(), issueId, userId)
+}
+
+// RemoveIssueWatcherContext is like RemoveIssueWatcher but uses ctx for the
+// request.
+func (c *Client) RemoveIssueWatcherContext(ctx context.Context, issueId, userId int) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", c.endpoint+"/issues/"+strconv.Itoa(issueId)+"/watchers/"+strconv.Itoa(userId)+".json", strings.NewReader(""))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode/100 != 2 {
+		return errorFromResp(res)
+	}
+	return nil
+}
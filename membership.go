@@ -1,8 +1,8 @@
 package redmine
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
 	"net/http"
 	"strconv"
 	"strings"
@@ -10,6 +10,7 @@ import (
 
 type membershipsResult struct {
 	Memberships []Membership `json:"memberships"`
+	page
 }
 
 type membershipResult struct {
@@ -29,30 +30,29 @@ type Membership struct {
 }
 
 func (c *Client) Memberships(projectId int) ([]Membership, error) {
-	res, err := c.Get(c.endpoint + "/projects/" + strconv.Itoa(projectId) + "/memberships.json?key=" + c.apikey + c.getPaginationClause())
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
+	return c.MembershipsContext(context.Background(), projectId)
+}
 
-	decoder := json.NewDecoder(res.Body)
-	var r membershipsResult
-	if res.StatusCode == 404 {
-		return nil, errors.New("Not Found")
-	}
-	if res.StatusCode != 200 {
-		err = errorFromResp(decoder, res.StatusCode)
-	} else {
-		err = decoder.Decode(&r)
-	}
+func (c *Client) MembershipsContext(ctx context.Context, projectId int, opts ...ListOption) ([]Membership, error) {
+	var list []Membership
+	err := c.getPages(ctx, "/projects/"+strconv.Itoa(projectId)+"/memberships.json", nil, opts, func(decoder *json.Decoder) (page, int, error) {
+		var r membershipsResult
+		err := decoder.Decode(&r)
+		list = append(list, r.Memberships...)
+		return r.page, len(r.Memberships), err
+	})
 	if err != nil {
 		return nil, err
 	}
-	return r.Memberships, nil
+	return list, nil
 }
 
 func (c *Client) Membership(id int) (*Membership, error) {
-	res, err := c.Get(c.endpoint + "/memberships/" + strconv.Itoa(id) + ".json?key=" + c.apikey)
+	return c.MembershipContext(context.Background(), id)
+}
+
+func (c *Client) MembershipContext(ctx context.Context, id int) (*Membership, error) {
+	res, err := c.get(ctx, c.endpoint+"/memberships/"+strconv.Itoa(id)+".json")
 	if err != nil {
 		return nil, err
 	}
@@ -60,11 +60,8 @@ func (c *Client) Membership(id int) (*Membership, error) {
 
 	decoder := json.NewDecoder(res.Body)
 	var r membershipResult
-	if res.StatusCode == 404 {
-		return nil, errors.New("Not Found")
-	}
 	if res.StatusCode != 200 {
-		err = errorFromResp(decoder, res.StatusCode)
+		err = errorFromResp(res)
 	} else {
 		err = decoder.Decode(&r)
 	}
@@ -75,18 +72,22 @@ func (c *Client) Membership(id int) (*Membership, error) {
 }
 
 func (c *Client) CreateMembership(membership Membership) (*Membership, error) {
+	return c.CreateMembershipContext(context.Background(), membership)
+}
+
+func (c *Client) CreateMembershipContext(ctx context.Context, membership Membership) (*Membership, error) {
 	var ir membershipRequest
 	ir.Membership = membership
 	s, err := json.Marshal(ir)
 	if err != nil {
 		return nil, err
 	}
-	req, err := http.NewRequest("POST", c.endpoint+"/memberships.json?key="+c.apikey, strings.NewReader(string(s)))
+	req, err := http.NewRequestWithContext(ctx, "POST", c.endpoint+"/memberships.json", strings.NewReader(string(s)))
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	res, err := c.Do(req)
+	res, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -95,7 +96,7 @@ func (c *Client) CreateMembership(membership Membership) (*Membership, error) {
 	decoder := json.NewDecoder(res.Body)
 	var r membershipRequest
 	if res.StatusCode != 201 {
-		err = errorFromResp(decoder, res.StatusCode)
+		err = errorFromResp(res)
 	} else {
 		err = decoder.Decode(&r)
 	}
@@ -106,55 +107,78 @@ func (c *Client) CreateMembership(membership Membership) (*Membership, error) {
 }
 
 func (c *Client) UpdateMembership(membership Membership) error {
+	return c.UpdateMembershipContext(context.Background(), membership)
+}
+
+func (c *Client) UpdateMembershipContext(ctx context.Context, membership Membership) error {
 	var ir membershipRequest
 	ir.Membership = membership
 	s, err := json.Marshal(ir)
 	if err != nil {
 		return err
 	}
-	req, err := http.NewRequest("PUT", c.endpoint+"/memberships/"+strconv.Itoa(membership.Id)+".json?key="+c.apikey, strings.NewReader(string(s)))
+	req, err := http.NewRequestWithContext(ctx, "PUT", c.endpoint+"/memberships/"+strconv.Itoa(membership.Id)+".json", strings.NewReader(string(s)))
 	if err != nil {
 		return err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	res, err := c.Do(req)
+	res, err := c.do(req)
 	if err != nil {
 		return err
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode == 404 {
-		return errors.New("Not Found")
+	if res.StatusCode/100 != 2 {
+		return errorFromResp(res)
 	}
-	if res.StatusCode != 200 {
-		decoder := json.NewDecoder(res.Body)
-		err = errorFromResp(decoder, res.StatusCode)
-	}
-	if err != nil {
-		return err
-	}
-	return err
+	return nil
 }
 
 func (c *Client) DeleteMembership(id int) error {
-	req, err := http.NewRequest("DELETE", c.endpoint+"/memberships/"+strconv.Itoa(id)+".json?key="+c.apikey, strings.NewReader(""))
+	return c.DeleteMembershipContext(context.Background(), id)
+}
+
+func (c *Client) DeleteMembershipContext(ctx context.Context, id int) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", c.endpoint+"/memberships/"+strconv.Itoa(id)+".json", strings.NewReader(""))
 	if err != nil {
 		return err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	res, err := c.Do(req)
+	res, err := c.do(req)
 	if err != nil {
 		return err
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode == 404 {
-		return errors.New("Not Found")
+	if res.StatusCode/100 != 2 {
+		return errorFromResp(res)
 	}
+	return nil
+}
 
-	decoder := json.NewDecoder(res.Body)
-	if res.StatusCode != 200 {
-		err = errorFromResp(decoder, res.StatusCode)
-	}
-	return err
+// MembershipIterator walks through memberships page by page.
+type MembershipIterator struct {
+	pager
+	memberships []Membership
+}
+
+// IterMemberships returns an iterator over the memberships of the given project.
+func (c *Client) IterMemberships(ctx context.Context, projectId int, opts ...ListOption) *MembershipIterator {
+	return &MembershipIterator{pager: c.newPager(ctx, "/projects/"+strconv.Itoa(projectId)+"/memberships.json", nil, opts)}
+}
+
+// Next advances to the next membership. It returns false at the end of
+// the list or when an error occurred, see Err.
+func (it *MembershipIterator) Next() bool {
+	return it.next(func(decoder *json.Decoder) (page, int, error) {
+		var r membershipsResult
+		err := decoder.Decode(&r)
+		it.memberships = r.Memberships
+		return r.page, len(r.Memberships), err
+	})
+}
+
+// Membership returns the current membership.
+func (it *MembershipIterator) Membership() *Membership {
+	return &it.memberships[it.pos]
 }
@@ -1,48 +1,66 @@
 package redmine
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
 	"strconv"
-	"strings"
 )
 
 type newsResult struct {
 	News []News `json:"news"`
+	page
 }
 
 type News struct {
-	Id          int    `json:"id"`
-	Project     IdName `json:"project"`
-	Title       string `json:"title"`
-	Summary     string `json:"summary"`
-	Description string `json:"description"`
-	CreatedOn   string `json:"created_on"`
+	Id          int      `json:"id"`
+	Project     IdName   `json:"project"`
+	Title       string   `json:"title"`
+	Summary     string   `json:"summary"`
+	Description string   `json:"description"`
+	CreatedOn   DateTime `json:"created_on"`
 }
 
 func (c *Client) News(projectId int) ([]News, error) {
-	res, err := c.Get(c.endpoint + "/projects/" + strconv.Itoa(projectId) + "/news.json?key=" + c.apikey + c.getPaginationClause())
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
+	return c.NewsContext(context.Background(), projectId)
+}
 
-	decoder := json.NewDecoder(res.Body)
-	var r newsResult
-	if res.StatusCode == 404 {
-		return nil, errors.New("Not Found")
-	}
-	if res.StatusCode != 200 {
-		var er errorsResult
-		err = decoder.Decode(&er)
-		if err == nil {
-			err = errors.New(strings.Join(er.Errors, "\n"))
-		}
-	} else {
-		err = decoder.Decode(&r)
-	}
+func (c *Client) NewsContext(ctx context.Context, projectId int, opts ...ListOption) ([]News, error) {
+	var list []News
+	err := c.getPages(ctx, "/projects/"+strconv.Itoa(projectId)+"/news.json", nil, opts, func(decoder *json.Decoder) (page, int, error) {
+		var r newsResult
+		err := decoder.Decode(&r)
+		list = append(list, r.News...)
+		return r.page, len(r.News), err
+	})
 	if err != nil {
 		return nil, err
 	}
-	return r.News, nil
+	return list, nil
+}
+
+// NewsIterator walks through news page by page.
+type NewsIterator struct {
+	pager
+	news []News
+}
+
+// IterNews returns an iterator over the news of the given project.
+func (c *Client) IterNews(ctx context.Context, projectId int, opts ...ListOption) *NewsIterator {
+	return &NewsIterator{pager: c.newPager(ctx, "/projects/"+strconv.Itoa(projectId)+"/news.json", nil, opts)}
+}
+
+// Next advances to the next news item. It returns false at the end of
+// the list or when an error occurred, see Err.
+func (it *NewsIterator) Next() bool {
+	return it.next(func(decoder *json.Decoder) (page, int, error) {
+		var r newsResult
+		err := decoder.Decode(&r)
+		it.news = r.News
+		return r.page, len(r.News), err
+	})
+}
+
+// News returns the current news item.
+func (it *NewsIterator) News() *News {
+	return &it.news[it.pos]
 }
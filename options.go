@@ -0,0 +1,99 @@
+package redmine
+
+import (
+	"net/http"
+	"time"
+)
+
+// Option configures a Client created by NewClient.
+type Option func(*Client)
+
+// Logger is the interface used to log requests, see WithLogger. It is
+// implemented by *log.Logger.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// WithHTTPClient makes the client send requests with hc instead of
+// http.DefaultClient.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.Client = hc
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(ua string) Option {
+	return func(c *Client) {
+		c.userAgent = ua
+	}
+}
+
+// WithAPIKey authenticates requests with the given API key.
+func WithAPIKey(apikey string) Option {
+	return func(c *Client) {
+		c.apikey = apikey
+	}
+}
+
+// WithBasicAuth authenticates requests with the given login and password
+// instead of the API key.
+func WithBasicAuth(login, password string) Option {
+	return func(c *Client) {
+		c.login = login
+		c.password = password
+	}
+}
+
+// WithSwitchUser makes requests act on behalf of the user with the given
+// login, see Client.As.
+func WithSwitchUser(login string) Option {
+	return func(c *Client) {
+		c.switchUser = login
+	}
+}
+
+// WithPageSize sets the number of items list calls request per page.
+// Redmine caps it at 100 by default.
+func WithPageSize(n int) Option {
+	return func(c *Client) {
+		c.Limit = n
+	}
+}
+
+// WithTimeout sets a time limit for each request, including reading the
+// response body. The HTTP client given by WithHTTPClient is not modified.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = d
+	}
+}
+
+// WithLogger makes the client log every request and its outcome to l.
+// Credentials are redacted from the logged URLs.
+func WithLogger(l Logger) Option {
+	return func(c *Client) {
+		c.logger = l
+	}
+}
+
+// WithRetry makes the client retry failed requests according to p.
+func WithRetry(p RetryPolicy) Option {
+	return func(c *Client) {
+		c.Retry = &p
+	}
+}
+
+// WithThrottle makes the client wait for t before sending requests.
+func WithThrottle(t *Throttle) Option {
+	return func(c *Client) {
+		c.Throttle = t
+	}
+}
+
+// WithPageWorkers sets the number of issue pages fetched concurrently.
+func WithPageWorkers(n int) Option {
+	return func(c *Client) {
+		c.PageWorkers = n
+	}
+}
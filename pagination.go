@@ -0,0 +1,247 @@
+package redmine
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// page is the pagination metadata Redmine adds to list responses.
+type page struct {
+	TotalCount int `json:"total_count"`
+	Offset     int `json:"offset"`
+	Limit      int `json:"limit"`
+}
+
+// ListOption configures the pagination and order of a single list call.
+// Options given to a call take precedence over the Limit and Offset fields
+// of the Client.
+type ListOption func(*listOptions)
+
+type listOptions struct {
+	limit  int
+	offset int
+	sort   []string
+}
+
+// Limit sets the number of items requested per page. Redmine caps it at
+// 100 by default.
+func Limit(n int) ListOption {
+	return func(o *listOptions) {
+		o.limit = n
+	}
+}
+
+// Offset makes the listing start at the n-th item.
+func Offset(n int) ListOption {
+	return func(o *listOptions) {
+		o.offset = n
+	}
+}
+
+// Sort orders the items by the given fields, each optionally followed by
+// ":desc", e.g. Sort("priority:desc", "id").
+func Sort(fields ...string) ListOption {
+	return func(o *listOptions) {
+		o.sort = fields
+	}
+}
+
+// pager walks a list endpoint page by page, starting at the requested
+// offset, until the total_count reported by Redmine is reached. Endpoints
+// that do not report a total_count are fetched once. A page is only
+// fetched when Next runs past the current one, so callers of the iterators
+// can stop early without fetching the rest of the list.
+//
+// pager is embedded by the typed iterators, which provide the decode
+// function that stores the items of a page.
+type pager struct {
+	ctx    context.Context
+	c      *Client
+	path   string
+	params *Filter
+	offset int
+	page   page
+	n      int // number of items in the current page
+	pos    int // position of the current item in the current page
+	done   bool
+	err    error
+}
+
+func (c *Client) newPager(ctx context.Context, path string, f *Filter, opts []ListOption) pager {
+	o := listOptions{limit: c.Limit, offset: c.Offset}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	params := f.clone()
+	if o.limit > -1 {
+		params.AddPair("limit", strconv.Itoa(o.limit))
+	}
+	if len(o.sort) > 0 {
+		params.AddPair("sort", strings.Join(o.sort, ","))
+	}
+	offset := 0
+	if o.offset > -1 {
+		offset = o.offset
+	}
+	return pager{ctx: ctx, c: c, path: path, params: params, offset: offset}
+}
+
+// fetch requests the next page and hands its body to decode, which returns
+// the page metadata together with the number of items it read. It reports
+// whether a non-empty page was read.
+func (p *pager) fetch(decode func(*json.Decoder) (page, int, error)) bool {
+	if p.done || p.err != nil {
+		return false
+	}
+	if p.err = p.ctx.Err(); p.err != nil {
+		return false
+	}
+	p.params.AddPair("offset", strconv.Itoa(p.offset))
+	p.page, p.n, p.err = p.c.getPage(p.ctx, p.path, p.params, decode)
+	if p.err != nil {
+		return false
+	}
+	p.pos = 0
+	p.offset += p.n
+	if p.n == 0 || p.offset >= p.page.TotalCount {
+		p.done = true
+	}
+	return p.n > 0
+}
+
+// next advances to the next item, fetching a new page when the current one
+// is exhausted.
+func (p *pager) next(decode func(*json.Decoder) (page, int, error)) bool {
+	if p.pos+1 < p.n {
+		p.pos++
+		return true
+	}
+	p.n = 0
+	return p.fetch(decode)
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (p *pager) Err() error {
+	return p.err
+}
+
+// TotalCount returns the total number of items reported by Redmine. It is
+// zero until the first page has been fetched.
+func (p *pager) TotalCount() int {
+	return p.page.TotalCount
+}
+
+// Offset returns the offset of the current page.
+func (p *pager) Offset() int {
+	return p.page.Offset
+}
+
+// Limit returns the size of the pages returned by Redmine.
+func (p *pager) Limit() int {
+	return p.page.Limit
+}
+
+// getPages fetches every page of path, see pager.
+func (c *Client) getPages(ctx context.Context, path string, f *Filter, opts []ListOption, decode func(*json.Decoder) (page, int, error)) error {
+	p := c.newPager(ctx, path, f, opts)
+	for p.fetch(decode) {
+	}
+	return p.err
+}
+
+// errListChanged is returned by getPagesConcurrently when the total count
+// reported by a page differs from the one of the first page, meaning items
+// were added or removed while the pages were fetched.
+var errListChanged = errors.New("redmine: list changed while fetching pages")
+
+// getPagesConcurrently fetches the first page of path to learn the total
+// count and page size, then the remaining pages with up to workers
+// concurrent requests. decode is called with the index of every page in
+// list order and must be safe for concurrent use. It returns
+// errListChanged if the total count changes from one page to another.
+func (c *Client) getPagesConcurrently(ctx context.Context, path string, f *Filter, opts []ListOption, workers int, decode func(int, *json.Decoder) (page, int, error)) error {
+	p := c.newPager(ctx, path, f, opts)
+	first := func(decoder *json.Decoder) (page, int, error) {
+		return decode(0, decoder)
+	}
+	if !p.fetch(first) || p.done {
+		return p.err
+	}
+	limit := strconv.Itoa(p.n)
+	var offsets []int
+	for offset := p.offset; offset < p.page.TotalCount; offset += p.n {
+		offsets = append(offsets, offset)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var (
+		wg   sync.WaitGroup
+		once sync.Once
+		err  error
+	)
+	jobs := make(chan int)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				params := p.params.clone()
+				params.AddPair("limit", limit)
+				params.AddPair("offset", strconv.Itoa(offsets[i]))
+				pg, _, e := c.getPage(ctx, path, params, func(decoder *json.Decoder) (page, int, error) {
+					return decode(i+1, decoder)
+				})
+				if e == nil && pg.TotalCount != p.page.TotalCount {
+					e = errListChanged
+				}
+				if e != nil {
+					once.Do(func() {
+						err = e
+						cancel()
+					})
+				}
+			}
+		}()
+	}
+feed:
+	for i := range offsets {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	if err == nil {
+		err = ctx.Err()
+	}
+	return err
+}
+
+func (c *Client) getPage(ctx context.Context, path string, params *Filter, decode func(*json.Decoder) (page, int, error)) (page, int, error) {
+	uri, err := c.url(path, params)
+	if err != nil {
+		return page{}, 0, err
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", uri, nil)
+	if err != nil {
+		return page{}, 0, err
+	}
+	res, err := c.do(req)
+	if err != nil {
+		return page{}, 0, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != 200 {
+		return page{}, 0, errorFromResp(res)
+	}
+	return decode(json.NewDecoder(res.Body))
+}
@@ -0,0 +1,179 @@
+package redmine
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// issueServer serves /issues.json from ids, calling hook before each page.
+type issueServer struct {
+	mu   sync.Mutex
+	ids  []int
+	hook func(r *http.Request, s *issueServer)
+}
+
+func (s *issueServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.hook != nil {
+		s.hook(r, s)
+	}
+	q := r.URL.Query()
+	offset, _ := strconv.Atoi(q.Get("offset"))
+	limit, _ := strconv.Atoi(q.Get("limit"))
+	if limit == 0 {
+		limit = 25
+	}
+	type issue struct {
+		Id int `json:"id"`
+	}
+	var r2 struct {
+		Issues []issue `json:"issues"`
+		page
+	}
+	s.mu.Lock()
+	r2.Issues = []issue{}
+	for i := offset; i < offset+limit && i < len(s.ids); i++ {
+		r2.Issues = append(r2.Issues, issue{s.ids[i]})
+	}
+	r2.page = page{TotalCount: len(s.ids), Offset: offset, Limit: limit}
+	s.mu.Unlock()
+	json.NewEncoder(w).Encode(r2)
+}
+
+func issueIds(issues []Issue) []int {
+	ids := make([]int, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.Id
+	}
+	return ids
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestIssuesConcurrentlyOrder(t *testing.T) {
+	s := &issueServer{}
+	for id := 1; id <= 23; id++ {
+		s.ids = append(s.ids, id)
+	}
+	s.hook = func(r *http.Request, s *issueServer) {
+		if got := r.URL.Query().Get("sort"); got != "id" {
+			t.Errorf("sort = %q, want id", got)
+		}
+		// answer the pages out of order
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		time.Sleep(time.Duration(30-offset) * time.Millisecond)
+	}
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "key", WithPageSize(3), WithPageWorkers(4))
+	issues, err := c.Issues()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := issueIds(issues); !equalInts(got, s.ids) {
+		t.Errorf("got %v, want %v", got, s.ids)
+	}
+}
+
+func TestIssuesConcurrentlyDedup(t *testing.T) {
+	s := &issueServer{ids: []int{1, 2, 3, 4, 5, 6}}
+	var once sync.Once
+	s.hook = func(r *http.Request, s *issueServer) {
+		if r.URL.Query().Get("offset") != "0" {
+			// an issue moved in front of the others after the first page
+			// was fetched shifts them without changing the total count
+			once.Do(func() {
+				s.mu.Lock()
+				s.ids = []int{6, 1, 2, 3, 4, 5}
+				s.mu.Unlock()
+			})
+		}
+	}
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "key", WithPageSize(2), WithPageWorkers(2))
+	issues, err := c.Issues()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := issueIds(issues), []int{6, 1, 2, 3, 4, 5}; !equalInts(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestIssuesConcurrentlyRefetchOnGap(t *testing.T) {
+	s := &issueServer{ids: []int{1, 2, 3, 4, 5, 6, 7, 8}}
+	var once sync.Once
+	s.hook = func(r *http.Request, s *issueServer) {
+		if r.URL.Query().Get("offset") != "0" {
+			// issue 1 is closed after the first page was fetched
+			once.Do(func() {
+				s.mu.Lock()
+				s.ids = s.ids[1:]
+				s.mu.Unlock()
+			})
+		}
+	}
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "key", WithPageSize(2), WithPageWorkers(3))
+	issues, err := c.Issues()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := issueIds(issues), []int{2, 3, 4, 5, 6, 7, 8}; !equalInts(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestIssuesConcurrentlyCancelOnError(t *testing.T) {
+	s := &issueServer{}
+	for id := 1; id <= 40; id++ {
+		s.ids = append(s.ids, id)
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("offset") {
+		case "0":
+			s.ServeHTTP(w, r)
+		case "4":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			// the other pages hang until their request is cancelled
+			select {
+			case <-r.Context().Done():
+			case <-time.After(5 * time.Second):
+				s.ServeHTTP(w, r)
+			}
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "key", WithPageSize(2), WithPageWorkers(4))
+	start := time.Now()
+	_, err := c.Issues()
+	var e *Error
+	if !errors.As(err, &e) || e.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("err = %v, want a 500 *Error", err)
+	}
+	if d := time.Since(start); d > 2*time.Second {
+		t.Errorf("took %v, the other pages were not cancelled", d)
+	}
+}
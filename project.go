@@ -1,8 +1,8 @@
 package redmine
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
 	"net/http"
 	"strconv"
 	"strings"
@@ -18,6 +18,7 @@ type projectResult struct {
 
 type projectsResult struct {
 	Projects []Project `json:"projects"`
+	page
 }
 
 type Project struct {
@@ -26,13 +27,17 @@ type Project struct {
 	Name         string         `json:"name"`
 	Identifier   string         `json:"identifier"`
 	Description  string         `json:"description"`
-	CreatedOn    string         `json:"created_on"`
-	UpdatedOn    string         `json:"updated_on"`
+	CreatedOn    DateTime       `json:"created_on"`
+	UpdatedOn    DateTime       `json:"updated_on"`
 	CustomFields []*CustomField `json:"custom_fields,omitempty"`
 }
 
 func (c *Client) Project(id int) (*Project, error) {
-	res, err := c.Get(c.endpoint + "/projects/" + strconv.Itoa(id) + ".json?key=" + c.apikey)
+	return c.ProjectContext(context.Background(), id)
+}
+
+func (c *Client) ProjectContext(ctx context.Context, id int) (*Project, error) {
+	res, err := c.get(ctx, c.endpoint+"/projects/"+strconv.Itoa(id)+".json")
 	if err != nil {
 		return nil, err
 	}
@@ -41,11 +46,7 @@ func (c *Client) Project(id int) (*Project, error) {
 	decoder := json.NewDecoder(res.Body)
 	var r projectResult
 	if res.StatusCode != 200 {
-		var er errorsResult
-		err = decoder.Decode(&er)
-		if err == nil {
-			err = errors.New(strings.Join(er.Errors, "\n"))
-		}
+		err = errorFromResp(res)
 	} else {
 		err = decoder.Decode(&r)
 	}
@@ -56,42 +57,40 @@ func (c *Client) Project(id int) (*Project, error) {
 }
 
 func (c *Client) Projects() ([]Project, error) {
-	res, err := c.Get(c.endpoint + "/projects.json?key=" + c.apikey + c.getPaginationClause())
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
+	return c.ProjectsContext(context.Background())
+}
 
-	decoder := json.NewDecoder(res.Body)
-	var r projectsResult
-	if res.StatusCode != 200 {
-		var er errorsResult
-		err = decoder.Decode(&er)
-		if err == nil {
-			err = errors.New(strings.Join(er.Errors, "\n"))
-		}
-	} else {
-		err = decoder.Decode(&r)
-	}
+func (c *Client) ProjectsContext(ctx context.Context, opts ...ListOption) ([]Project, error) {
+	var list []Project
+	err := c.getPages(ctx, "/projects.json", nil, opts, func(decoder *json.Decoder) (page, int, error) {
+		var r projectsResult
+		err := decoder.Decode(&r)
+		list = append(list, r.Projects...)
+		return r.page, len(r.Projects), err
+	})
 	if err != nil {
 		return nil, err
 	}
-	return r.Projects, nil
+	return list, nil
 }
 
 func (c *Client) CreateProject(project Project) (*Project, error) {
+	return c.CreateProjectContext(context.Background(), project)
+}
+
+func (c *Client) CreateProjectContext(ctx context.Context, project Project) (*Project, error) {
 	var ir projectRequest
 	ir.Project = project
 	s, err := json.Marshal(ir)
 	if err != nil {
 		return nil, err
 	}
-	req, err := http.NewRequest("POST", c.endpoint+"/projects.json?key="+c.apikey, strings.NewReader(string(s)))
+	req, err := http.NewRequestWithContext(ctx, "POST", c.endpoint+"/projects.json", strings.NewReader(string(s)))
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	res, err := c.Do(req)
+	res, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -100,11 +99,7 @@ func (c *Client) CreateProject(project Project) (*Project, error) {
 	decoder := json.NewDecoder(res.Body)
 	var r projectRequest
 	if res.StatusCode != 201 {
-		var er errorsResult
-		err = decoder.Decode(&er)
-		if err == nil {
-			err = errors.New(strings.Join(er.Errors, "\n"))
-		}
+		err = errorFromResp(res)
 	} else {
 		err = decoder.Decode(&r)
 	}
@@ -115,63 +110,78 @@ func (c *Client) CreateProject(project Project) (*Project, error) {
 }
 
 func (c *Client) UpdateProject(project Project) error {
+	return c.UpdateProjectContext(context.Background(), project)
+}
+
+func (c *Client) UpdateProjectContext(ctx context.Context, project Project) error {
 	var ir projectRequest
 	ir.Project = project
 	s, err := json.Marshal(ir)
 	if err != nil {
 		return err
 	}
-	req, err := http.NewRequest("PUT", c.endpoint+"/projects/"+strconv.Itoa(project.Id)+".json?key="+c.apikey, strings.NewReader(string(s)))
+	req, err := http.NewRequestWithContext(ctx, "PUT", c.endpoint+"/projects/"+strconv.Itoa(project.Id)+".json", strings.NewReader(string(s)))
 	if err != nil {
 		return err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	res, err := c.Do(req)
+	res, err := c.do(req)
 	if err != nil {
 		return err
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode == 404 {
-		return errors.New("Not Found")
+	if res.StatusCode/100 != 2 {
+		return errorFromResp(res)
 	}
-	if res.StatusCode != 200 {
-		decoder := json.NewDecoder(res.Body)
-		var er errorsResult
-		err = decoder.Decode(&er)
-		if err == nil {
-			err = errors.New(strings.Join(er.Errors, "\n"))
-		}
-	}
-	if err != nil {
-		return err
-	}
-	return err
+	return nil
 }
 
 func (c *Client) DeleteProject(id int) error {
-	req, err := http.NewRequest("DELETE", c.endpoint+"/projects/"+strconv.Itoa(id)+".json?key="+c.apikey, strings.NewReader(""))
+	return c.DeleteProjectContext(context.Background(), id)
+}
+
+func (c *Client) DeleteProjectContext(ctx context.Context, id int) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", c.endpoint+"/projects/"+strconv.Itoa(id)+".json", strings.NewReader(""))
 	if err != nil {
 		return err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	res, err := c.Do(req)
+	res, err := c.do(req)
 	if err != nil {
 		return err
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode == 404 {
-		return errors.New("Not Found")
+	if res.StatusCode/100 != 2 {
+		return errorFromResp(res)
 	}
+	return nil
+}
 
-	decoder := json.NewDecoder(res.Body)
-	if res.StatusCode != 200 {
-		var er errorsResult
-		err = decoder.Decode(&er)
-		if err == nil {
-			err = errors.New(strings.Join(er.Errors, "\n"))
-		}
-	}
-	return err
+// ProjectIterator walks through projects page by page.
+type ProjectIterator struct {
+	pager
+	projects []Project
+}
+
+// IterProjects returns an iterator over the projects.
+func (c *Client) IterProjects(ctx context.Context, opts ...ListOption) *ProjectIterator {
+	return &ProjectIterator{pager: c.newPager(ctx, "/projects.json", nil, opts)}
+}
+
+// Next advances to the next project. It returns false at the end of
+// the list or when an error occurred, see Err.
+func (it *ProjectIterator) Next() bool {
+	return it.next(func(decoder *json.Decoder) (page, int, error) {
+		var r projectsResult
+		err := decoder.Decode(&r)
+		it.projects = r.Projects
+		return r.page, len(r.Projects), err
+	})
+}
+
+// Project returns the current project.
+func (it *ProjectIterator) Project() *Project {
+	return &it.projects[it.pos]
 }
@@ -0,0 +1,103 @@
+package redmine
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+type queriesResult struct {
+	Queries []Query `json:"queries"`
+	page
+}
+
+// Query is a saved issue query.
+type Query struct {
+	Id        int    `json:"id"`
+	Name      string `json:"name"`
+	IsPublic  bool   `json:"is_public"`
+	ProjectId int    `json:"project_id"` // 0 for queries of all projects
+}
+
+// Queries returns the saved queries visible to the user.
+func (c *Client) Queries() ([]Query, error) {
+	return c.QueriesContext(context.Background())
+}
+
+// QueriesContext is like Queries but uses ctx for the requests.
+func (c *Client) QueriesContext(ctx context.Context, opts ...ListOption) ([]Query, error) {
+	var list []Query
+	err := c.getPages(ctx, "/queries.json", nil, opts, func(decoder *json.Decoder) (page, int, error) {
+		var r queriesResult
+		err := decoder.Decode(&r)
+		list = append(list, r.Queries...)
+		return r.page, len(r.Queries), err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// QueryByName returns the saved query with the given name which can be run
+// in the project with the given id, preferring a query of that project over
+// a query of all projects. A projectId of 0 only matches queries of all
+// projects. The error matches ErrNotFound if there is no such query.
+func (c *Client) QueryByName(projectId int, name string) (*Query, error) {
+	return c.QueryByNameContext(context.Background(), projectId, name)
+}
+
+// QueryByNameContext is like QueryByName but uses ctx for the requests.
+func (c *Client) QueryByNameContext(ctx context.Context, projectId int, name string) (*Query, error) {
+	queries, err := c.QueriesContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var found *Query
+	for i, q := range queries {
+		if q.Name != name {
+			continue
+		}
+		if q.ProjectId == projectId && projectId != 0 {
+			return &queries[i], nil
+		}
+		if q.ProjectId == 0 && found == nil {
+			found = &queries[i]
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("%w: query %q", ErrNotFound, name)
+	}
+	return found, nil
+}
+
+// IssueFilter returns an IssueFilter running q, to be given to
+// IssuesByFilter or IterIssues. Queries of all projects are run in the
+// project with the given id, or across projects for 0.
+func (q *Query) IssueFilter(projectId int) *IssueFilter {
+	f := &IssueFilter{Params: NewFilter("query_id", strconv.Itoa(q.Id))}
+	if q.ProjectId != 0 {
+		projectId = q.ProjectId
+	}
+	if projectId != 0 {
+		f.ProjectId = strconv.Itoa(projectId)
+	}
+	return f
+}
+
+// IssuesByQueryName returns the issues listed by the saved query with the
+// given name, see QueryByName.
+func (c *Client) IssuesByQueryName(projectId int, name string) ([]Issue, error) {
+	return c.IssuesByQueryNameContext(context.Background(), projectId, name)
+}
+
+// IssuesByQueryNameContext is like IssuesByQueryName but uses ctx for the
+// requests.
+func (c *Client) IssuesByQueryNameContext(ctx context.Context, projectId int, name string, opts ...ListOption) ([]Issue, error) {
+	q, err := c.QueryByNameContext(ctx, projectId, name)
+	if err != nil {
+		return nil, err
+	}
+	return c.IssuesByFilterContext(ctx, q.IssueFilter(projectId), opts...)
+}
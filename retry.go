@@ -0,0 +1,97 @@
+package redmine
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy describes how requests failing with a network error or a
+// 429, 502, 503 or 504 response are retried. Only idempotent requests are
+// retried unless RetryPOST is set, and requests whose body can not be sent
+// again are never retried. A Retry-After header asking for a longer wait
+// than MaxBackoff ends the retries.
+type RetryPolicy struct {
+	MaxAttempts int           // attempts including the first one
+	MinBackoff  time.Duration // wait before the first retry
+	MaxBackoff  time.Duration // upper bound of the wait between attempts
+	RetryPOST   bool          // also retry POST requests such as CreateIssue
+}
+
+// DefaultRetryPolicy retries idempotent requests twice.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	MinBackoff:  500 * time.Millisecond,
+	MaxBackoff:  30 * time.Second,
+}
+
+func (p *RetryPolicy) allows(req *http.Request) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		// the body can not be sent twice
+		return false
+	}
+	switch req.Method {
+	case "GET", "HEAD", "OPTIONS", "PUT", "DELETE":
+		return true
+	case "POST":
+		return p.RetryPOST
+	}
+	return false
+}
+
+// backoff returns how long to wait before the given retry. A Retry-After
+// header sent with res takes precedence over the exponential backoff; if it
+// asks for a longer wait than MaxBackoff, backoff returns false and the
+// request is not retried.
+func (p *RetryPolicy) backoff(retry int, res *http.Response) (time.Duration, bool) {
+	if res != nil {
+		if d, ok := retryAfter(res); ok {
+			if p.MaxBackoff > 0 && d > p.MaxBackoff {
+				return 0, false
+			}
+			return d, true
+		}
+	}
+	d := p.MinBackoff << uint(retry-1)
+	if p.MaxBackoff > 0 && (d <= 0 || d > p.MaxBackoff) {
+		d = p.MaxBackoff
+	}
+	// jitter in [d/2, d) so that clients failing together do not retry
+	// together
+	if d > 1 {
+		d = d/2 + time.Duration(rand.Int63n(int64(d/2)))
+	}
+	return d, true
+}
+
+// retryAfter parses the Retry-After header of res, given either in seconds
+// or as an HTTP date.
+func retryAfter(res *http.Response) (time.Duration, bool) {
+	s := res.Header.Get("Retry-After")
+	if s == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(s); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(s); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+func shouldRetry(res *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch res.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
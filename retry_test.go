@@ -0,0 +1,142 @@
+package redmine
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var testRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	MinBackoff:  time.Millisecond,
+	MaxBackoff:  10 * time.Millisecond,
+}
+
+func TestRetryAttempts(t *testing.T) {
+	tests := []struct {
+		status int
+		want   int32
+	}{
+		{http.StatusServiceUnavailable, 3},
+		{http.StatusTooManyRequests, 3},
+		{http.StatusNotFound, 1},
+		{http.StatusOK, 1},
+	}
+	for _, tt := range tests {
+		var n int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&n, 1)
+			w.WriteHeader(tt.status)
+			io.WriteString(w, `{"issue":{"id":1}}`)
+		}))
+		c := NewClient(srv.URL, "key", WithRetry(testRetryPolicy))
+		_, err := c.Issue(1)
+		srv.Close()
+		if got := atomic.LoadInt32(&n); got != tt.want {
+			t.Errorf("status %d: %d attempts, want %d", tt.status, got, tt.want)
+		}
+		var e *Error
+		if tt.status != http.StatusOK && (!errors.As(err, &e) || e.StatusCode != tt.status) {
+			t.Errorf("status %d: err = %v", tt.status, err)
+		}
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	p := RetryPolicy{MinBackoff: time.Second, MaxBackoff: time.Minute}
+	tests := []struct {
+		header string
+		want   time.Duration
+		ok     bool
+	}{
+		{"5", 5 * time.Second, true},
+		{"0", 0, true},
+		{time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), 0, true},
+		{"86400", 0, false},
+		{time.Now().Add(time.Hour).UTC().Format(http.TimeFormat), 0, false},
+	}
+	for _, tt := range tests {
+		res := &http.Response{Header: http.Header{"Retry-After": {tt.header}}}
+		d, ok := p.backoff(1, res)
+		if d != tt.want || ok != tt.ok {
+			t.Errorf("Retry-After %q: got %v, %v, want %v, %v", tt.header, d, ok, tt.want, tt.ok)
+		}
+	}
+
+	// an invalid header falls back to the exponential backoff
+	res := &http.Response{Header: http.Header{"Retry-After": {"soon"}}}
+	if d, ok := p.backoff(1, res); !ok || d < time.Second/2 || d >= time.Second {
+		t.Errorf("invalid Retry-After: got %v, %v", d, ok)
+	}
+}
+
+func TestRetryAfterTooLong(t *testing.T) {
+	var n int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&n, 1)
+		w.Header().Set("Retry-After", "86400")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "key", WithRetry(testRetryPolicy))
+	start := time.Now()
+	_, err := c.Issue(1)
+	if d := time.Since(start); d > time.Second {
+		t.Errorf("waited %v", d)
+	}
+	if got := atomic.LoadInt32(&n); got != 1 {
+		t.Errorf("%d attempts, want 1", got)
+	}
+	var e *Error
+	if !errors.As(err, &e) || e.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("err = %v, want a 429 *Error", err)
+	}
+}
+
+func TestRetryBody(t *testing.T) {
+	var (
+		n      int32
+		bodies []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		if atomic.AddInt32(&n, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		io.WriteString(w, `{"upload":{"id":1,"token":"1.a"}}`)
+	}))
+	defer srv.Close()
+
+	// the body of an update is sent again with every attempt
+	c := NewClient(srv.URL, "key", WithRetry(testRetryPolicy))
+	if err := c.UpdateIssueWith(1, NewIssueFields().Subject("x")); err != nil {
+		t.Fatal(err)
+	}
+	want := `{"issue":{"subject":"x"}}`
+	if len(bodies) != 3 || bodies[0] != want || bodies[2] != want {
+		t.Errorf("bodies = %q", bodies)
+	}
+
+	// a streamed body can not be sent twice, even if POST may be retried
+	n, bodies = 0, nil
+	p := testRetryPolicy
+	p.RetryPOST = true
+	c = NewClient(srv.URL, "key", WithRetry(p))
+	r := struct{ io.Reader }{strings.NewReader("hello")}
+	if _, err := c.UploadReader(r, 5, "a.txt", nil); err == nil {
+		t.Error("streamed upload retried")
+	}
+	if len(bodies) != 1 {
+		t.Errorf("%d attempts, want 1", len(bodies))
+	}
+}
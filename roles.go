@@ -1,9 +1,8 @@
 package redmine
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
-	"strings"
 )
 
 type rolesResult struct {
@@ -11,7 +10,11 @@ type rolesResult struct {
 }
 
 func (c *Client) Roles() ([]IdName, error) {
-	res, err := c.Get(c.endpoint + "/roles.json?key=" + c.apikey + c.getPaginationClause())
+	return c.RolesContext(context.Background())
+}
+
+func (c *Client) RolesContext(ctx context.Context) ([]IdName, error) {
+	res, err := c.get(ctx, c.endpoint+"/roles.json")
 	if err != nil {
 		return nil, err
 	}
@@ -20,11 +23,7 @@ func (c *Client) Roles() ([]IdName, error) {
 	decoder := json.NewDecoder(res.Body)
 	var r rolesResult
 	if res.StatusCode != 200 {
-		var er errorsResult
-		err = decoder.Decode(&er)
-		if err == nil {
-			err = errors.New(strings.Join(er.Errors, "\n"))
-		}
+		err = errorFromResp(res)
 	} else {
 		err = decoder.Decode(&r)
 	}
@@ -0,0 +1,140 @@
+package redmine
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+)
+
+type searchResult struct {
+	Results []SearchResult `json:"results"`
+	page
+}
+
+// SearchResult is an item found by Search.
+type SearchResult struct {
+	Id          int      `json:"id"`
+	Title       string   `json:"title"`
+	Type        string   `json:"type"` // e.g. "issue", "issue closed", "wiki-page"
+	URL         string   `json:"url"`
+	Description string   `json:"description"`
+	Datetime    DateTime `json:"datetime"`
+}
+
+// Scopes of SearchOptions.
+const (
+	SearchScopeAll         string = "all"
+	SearchScopeMyProjects  string = "my_projects"
+	SearchScopeSubprojects string = "subprojects"
+)
+
+// Resource types of SearchOptions.
+const (
+	SearchIssues     string = "issues"
+	SearchNews       string = "news"
+	SearchDocuments  string = "documents"
+	SearchChangesets string = "changesets"
+	SearchWikiPages  string = "wiki_pages"
+	SearchMessages   string = "messages"
+	SearchProjects   string = "projects"
+)
+
+// Attachment modes of SearchOptions.
+const (
+	SearchAttachmentsNone string = "0"    // search the resources only
+	SearchAttachmentsToo  string = "1"    // search the resources and their attachments
+	SearchAttachmentsOnly string = "only" // search the attachments only
+)
+
+// SearchOptions narrows down a search. The zero value searches all the
+// resource types of all the projects visible to the user.
+type SearchOptions struct {
+	ProjectId   int      // search within this project only
+	Scope       string   // see SearchScopeAll and friends
+	Types       []string // resource types to search, see SearchIssues and friends
+	AllWords    bool     // match all the words instead of any of them
+	TitlesOnly  bool
+	OpenIssues  bool   // only search open issues
+	Attachments string // see SearchAttachmentsNone and friends
+}
+
+func (o *SearchOptions) path() string {
+	if o != nil && o.ProjectId != 0 {
+		return "/projects/" + strconv.Itoa(o.ProjectId) + "/search.json"
+	}
+	return "/search.json"
+}
+
+func (o *SearchOptions) filter(q string) *Filter {
+	f := NewFilter("q", q)
+	if o == nil {
+		return f
+	}
+	if o.Scope != "" {
+		f.Set("scope", o.Scope)
+	}
+	for _, t := range o.Types {
+		f.Set(t, "1")
+	}
+	if o.AllWords {
+		f.Set("all_words", "1")
+	}
+	if o.TitlesOnly {
+		f.Set("titles_only", "1")
+	}
+	if o.OpenIssues {
+		f.Set("open_issues", "1")
+	}
+	if o.Attachments != "" {
+		f.Set("attachments", o.Attachments)
+	}
+	return f
+}
+
+// Search returns the resources matching q. o may be nil.
+func (c *Client) Search(q string, o *SearchOptions) ([]SearchResult, error) {
+	return c.SearchContext(context.Background(), q, o)
+}
+
+// SearchContext is like Search but uses ctx for the requests.
+func (c *Client) SearchContext(ctx context.Context, q string, o *SearchOptions, opts ...ListOption) ([]SearchResult, error) {
+	var list []SearchResult
+	err := c.getPages(ctx, o.path(), o.filter(q), opts, func(decoder *json.Decoder) (page, int, error) {
+		var r searchResult
+		err := decoder.Decode(&r)
+		list = append(list, r.Results...)
+		return r.page, len(r.Results), err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// SearchIterator walks through search results page by page.
+type SearchIterator struct {
+	pager
+	results []SearchResult
+}
+
+// IterSearch returns an iterator over the resources matching q. o may be
+// nil.
+func (c *Client) IterSearch(ctx context.Context, q string, o *SearchOptions, opts ...ListOption) *SearchIterator {
+	return &SearchIterator{pager: c.newPager(ctx, o.path(), o.filter(q), opts)}
+}
+
+// Next advances to the next result. It returns false at the end of
+// the list or when an error occurred, see Err.
+func (it *SearchIterator) Next() bool {
+	return it.next(func(decoder *json.Decoder) (page, int, error) {
+		var r searchResult
+		err := decoder.Decode(&r)
+		it.results = r.Results
+		return r.page, len(r.Results), err
+	})
+}
+
+// Result returns the current result.
+func (it *SearchIterator) Result() *SearchResult {
+	return &it.results[it.pos]
+}
@@ -0,0 +1,108 @@
+package redmine
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+)
+
+// Throttle limits the rate and the number of concurrent requests sent to
+// Redmine. It is safe for concurrent use and can be shared by several
+// clients using the same API key.
+type Throttle struct {
+	mu     sync.Mutex
+	rate   float64 // requests per second
+	burst  float64
+	tokens float64
+	last   time.Time
+	slots  chan struct{}
+}
+
+// NewThrottle returns a Throttle allowing rate requests per second with
+// bursts of up to burst requests, and at most maxInFlight requests at a
+// time. A zero rate or maxInFlight disables the respective limit.
+func NewThrottle(rate float64, burst, maxInFlight int) *Throttle {
+	if burst < 1 {
+		burst = 1
+	}
+	t := &Throttle{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+	if maxInFlight > 0 {
+		t.slots = make(chan struct{}, maxInFlight)
+	}
+	return t
+}
+
+// reserve takes a token from the bucket and returns how long to wait until
+// it becomes valid.
+func (t *Throttle) reserve() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := time.Now()
+	t.tokens += now.Sub(t.last).Seconds() * t.rate
+	if t.tokens > t.burst {
+		t.tokens = t.burst
+	}
+	t.last = now
+	t.tokens--
+	if t.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-t.tokens / t.rate * float64(time.Second))
+}
+
+func (t *Throttle) cancel() {
+	t.mu.Lock()
+	t.tokens++
+	t.mu.Unlock()
+}
+
+// acquire blocks until a request may be sent. The returned function must be
+// called once the request is done.
+func (t *Throttle) acquire(ctx context.Context) (func(), error) {
+	if t.rate > 0 {
+		if d := t.reserve(); d > 0 {
+			timer := time.NewTimer(d)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				t.cancel()
+				return nil, ctx.Err()
+			case <-timer.C:
+			}
+		}
+	}
+	if t.slots == nil {
+		return func() {}, nil
+	}
+	select {
+	case t.slots <- struct{}{}:
+	case <-ctx.Done():
+		if t.rate > 0 {
+			t.cancel()
+		}
+		return nil, ctx.Err()
+	}
+	var once sync.Once
+	return func() {
+		once.Do(func() { <-t.slots })
+	}, nil
+}
+
+// releaseBody releases the in-flight slot of a request when its response
+// body is closed.
+type releaseBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releaseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
+}
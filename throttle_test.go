@@ -0,0 +1,67 @@
+package redmine
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestThrottleCancelReturnsToken(t *testing.T) {
+	th := NewThrottle(0.001, 2, 1)
+	release, err := th.acquire(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := th.acquire(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("err = %v, want %v", err, context.DeadlineExceeded)
+	}
+	th.mu.Lock()
+	tokens := th.tokens
+	th.mu.Unlock()
+	if tokens < 1 {
+		t.Errorf("tokens = %v, want the token of the cancelled request back", tokens)
+	}
+}
+
+func TestThrottleReleasesSlot(t *testing.T) {
+	var status int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		io.WriteString(w, `{"issue":{"id":1}}`)
+	}))
+	defer srv.Close()
+
+	th := NewThrottle(0, 1, 1)
+	c := NewClient(srv.URL, "key", WithThrottle(th), WithRetry(testRetryPolicy))
+	for _, status = range []int{
+		http.StatusOK,
+		http.StatusNotFound,
+		http.StatusServiceUnavailable, // released between retries
+	} {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		_, err := c.IssueContext(ctx, 1)
+		cancel()
+		if err == context.DeadlineExceeded {
+			t.Fatalf("status %d: waited for a slot", status)
+		}
+		if n := len(th.slots); n != 0 {
+			t.Errorf("status %d: %d slots still held", status, n)
+		}
+	}
+
+	// network error
+	srv.Close()
+	if _, err := c.Issue(1); err == nil {
+		t.Error("no error from a closed server")
+	}
+	if n := len(th.slots); n != 0 {
+		t.Errorf("network error: %d slots still held", n)
+	}
+}
@@ -1,8 +1,8 @@
 package redmine
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
 	"net/http"
 	"strconv"
 	"strings"
@@ -10,6 +10,7 @@ import (
 
 type timeEntriesResult struct {
 	TimeEntries []TimeEntry `json:"time_entries"`
+	page
 }
 
 type timeEntryResult struct {
@@ -28,78 +29,56 @@ type TimeEntry struct {
 	Activity     IdName         `json:"activity"`
 	Hours        float32        `json:"hours"`
 	Comments     string         `json:"comments"`
-	SpentOn      string         `json:"spent_on"`
-	CreatedOn    string         `json:"created_on"`
-	UpdatedOn    string         `json:"updated_on"`
+	SpentOn      Date           `json:"spent_on"`
+	CreatedOn    DateTime       `json:"created_on"`
+	UpdatedOn    DateTime       `json:"updated_on"`
 	CustomFields []*CustomField `json:"custom_fields,omitempty"`
 }
 
 // TimeEntriesWithFilter send query and return parsed result
 func (c *Client) TimeEntriesWithFilter(filter Filter) ([]TimeEntry, error) {
-	uri, err := c.URLWithFilter("/time_entries.json", filter)
-	if err != nil {
-		return nil, err
-	}
-	req, err := http.NewRequest("GET", uri, nil)
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Add("X-Redmine-API-Key", c.apikey)
-	res, err := c.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
+	return c.TimeEntriesWithFilterContext(context.Background(), filter)
+}
 
-	decoder := json.NewDecoder(res.Body)
-	var r timeEntriesResult
-	if res.StatusCode == 404 {
-		return nil, errors.New("Not Found")
-	}
-	if res.StatusCode != 200 {
-		var er errorsResult
-		err = decoder.Decode(&er)
-		if err == nil {
-			err = errors.New(strings.Join(er.Errors, "\n"))
-		}
-	} else {
-		err = decoder.Decode(&r)
-	}
+// TimeEntriesWithFilterContext is like TimeEntriesWithFilter but uses ctx for the request.
+func (c *Client) TimeEntriesWithFilterContext(ctx context.Context, filter Filter, opts ...ListOption) ([]TimeEntry, error) {
+	var list []TimeEntry
+	err := c.getPages(ctx, "/time_entries.json", &filter, opts, func(decoder *json.Decoder) (page, int, error) {
+		var r timeEntriesResult
+		err := decoder.Decode(&r)
+		list = append(list, r.TimeEntries...)
+		return r.page, len(r.TimeEntries), err
+	})
 	if err != nil {
 		return nil, err
 	}
-	return r.TimeEntries, nil
+	return list, nil
 }
 
 func (c *Client) TimeEntries(projectId int) ([]TimeEntry, error) {
-	res, err := c.Get(c.endpoint + "/projects/" + strconv.Itoa(projectId) + "/time_entries.json?key=" + c.apikey + c.getPaginationClause())
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
+	return c.TimeEntriesContext(context.Background(), projectId)
+}
 
-	decoder := json.NewDecoder(res.Body)
-	var r timeEntriesResult
-	if res.StatusCode == 404 {
-		return nil, errors.New("Not Found")
-	}
-	if res.StatusCode != 200 {
-		var er errorsResult
-		err = decoder.Decode(&er)
-		if err == nil {
-			err = errors.New(strings.Join(er.Errors, "\n"))
-		}
-	} else {
-		err = decoder.Decode(&r)
-	}
+func (c *Client) TimeEntriesContext(ctx context.Context, projectId int, opts ...ListOption) ([]TimeEntry, error) {
+	var list []TimeEntry
+	err := c.getPages(ctx, "/projects/"+strconv.Itoa(projectId)+"/time_entries.json", nil, opts, func(decoder *json.Decoder) (page, int, error) {
+		var r timeEntriesResult
+		err := decoder.Decode(&r)
+		list = append(list, r.TimeEntries...)
+		return r.page, len(r.TimeEntries), err
+	})
 	if err != nil {
 		return nil, err
 	}
-	return r.TimeEntries, nil
+	return list, nil
 }
 
 func (c *Client) TimeEntry(id int) (*TimeEntry, error) {
-	res, err := c.Get(c.endpoint + "/time_entries/" + strconv.Itoa(id) + ".json?key=" + c.apikey)
+	return c.TimeEntryContext(context.Background(), id)
+}
+
+func (c *Client) TimeEntryContext(ctx context.Context, id int) (*TimeEntry, error) {
+	res, err := c.get(ctx, c.endpoint+"/time_entries/"+strconv.Itoa(id)+".json")
 	if err != nil {
 		return nil, err
 	}
@@ -107,15 +86,8 @@ func (c *Client) TimeEntry(id int) (*TimeEntry, error) {
 
 	decoder := json.NewDecoder(res.Body)
 	var r timeEntryResult
-	if res.StatusCode == 404 {
-		return nil, errors.New("Not Found")
-	}
 	if res.StatusCode != 200 {
-		var er errorsResult
-		err = decoder.Decode(&er)
-		if err == nil {
-			err = errors.New(strings.Join(er.Errors, "\n"))
-		}
+		err = errorFromResp(res)
 	} else {
 		err = decoder.Decode(&r)
 	}
@@ -126,18 +98,22 @@ func (c *Client) TimeEntry(id int) (*TimeEntry, error) {
 }
 
 func (c *Client) CreateTimeEntry(timeEntry TimeEntry) (*TimeEntry, error) {
+	return c.CreateTimeEntryContext(context.Background(), timeEntry)
+}
+
+func (c *Client) CreateTimeEntryContext(ctx context.Context, timeEntry TimeEntry) (*TimeEntry, error) {
 	var ir timeEntryRequest
 	ir.TimeEntry = timeEntry
 	s, err := json.Marshal(ir)
 	if err != nil {
 		return nil, err
 	}
-	req, err := http.NewRequest("POST", c.endpoint+"/time_entries.json?key="+c.apikey, strings.NewReader(string(s)))
+	req, err := http.NewRequestWithContext(ctx, "POST", c.endpoint+"/time_entries.json", strings.NewReader(string(s)))
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	res, err := c.Do(req)
+	res, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -146,11 +122,7 @@ func (c *Client) CreateTimeEntry(timeEntry TimeEntry) (*TimeEntry, error) {
 	decoder := json.NewDecoder(res.Body)
 	var r timeEntryResult
 	if res.StatusCode != 201 {
-		var er errorsResult
-		err = decoder.Decode(&er)
-		if err == nil {
-			err = errors.New(strings.Join(er.Errors, "\n"))
-		}
+		err = errorFromResp(res)
 	} else {
 		err = decoder.Decode(&r)
 	}
@@ -161,63 +133,78 @@ func (c *Client) CreateTimeEntry(timeEntry TimeEntry) (*TimeEntry, error) {
 }
 
 func (c *Client) UpdateTimeEntry(timeEntry TimeEntry) error {
+	return c.UpdateTimeEntryContext(context.Background(), timeEntry)
+}
+
+func (c *Client) UpdateTimeEntryContext(ctx context.Context, timeEntry TimeEntry) error {
 	var ir timeEntryRequest
 	ir.TimeEntry = timeEntry
 	s, err := json.Marshal(ir)
 	if err != nil {
 		return err
 	}
-	req, err := http.NewRequest("PUT", c.endpoint+"/time_entries/"+strconv.Itoa(timeEntry.Id)+".json?key="+c.apikey, strings.NewReader(string(s)))
+	req, err := http.NewRequestWithContext(ctx, "PUT", c.endpoint+"/time_entries/"+strconv.Itoa(timeEntry.Id)+".json", strings.NewReader(string(s)))
 	if err != nil {
 		return err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	res, err := c.Do(req)
+	res, err := c.do(req)
 	if err != nil {
 		return err
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode == 404 {
-		return errors.New("Not Found")
-	}
-	if res.StatusCode != 200 {
-		decoder := json.NewDecoder(res.Body)
-		var er errorsResult
-		err = decoder.Decode(&er)
-		if err == nil {
-			err = errors.New(strings.Join(er.Errors, "\n"))
-		}
-	}
-	if err != nil {
-		return err
+	if res.StatusCode/100 != 2 {
+		return errorFromResp(res)
 	}
-	return err
+	return nil
 }
 
 func (c *Client) DeleteTimeEntry(id int) error {
-	req, err := http.NewRequest("DELETE", c.endpoint+"/time_entries/"+strconv.Itoa(id)+".json?key="+c.apikey, strings.NewReader(""))
+	return c.DeleteTimeEntryContext(context.Background(), id)
+}
+
+func (c *Client) DeleteTimeEntryContext(ctx context.Context, id int) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", c.endpoint+"/time_entries/"+strconv.Itoa(id)+".json", strings.NewReader(""))
 	if err != nil {
 		return err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	res, err := c.Do(req)
+	res, err := c.do(req)
 	if err != nil {
 		return err
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode == 404 {
-		return errors.New("Not Found")
+	if res.StatusCode/100 != 2 {
+		return errorFromResp(res)
 	}
+	return nil
+}
 
-	decoder := json.NewDecoder(res.Body)
-	if res.StatusCode != 200 {
-		var er errorsResult
-		err = decoder.Decode(&er)
-		if err == nil {
-			err = errors.New(strings.Join(er.Errors, "\n"))
-		}
-	}
-	return err
+// TimeEntryIterator walks through time entries page by page.
+type TimeEntryIterator struct {
+	pager
+	entries []TimeEntry
+}
+
+// IterTimeEntries returns an iterator over the time entries matching filter.
+func (c *Client) IterTimeEntries(ctx context.Context, filter Filter, opts ...ListOption) *TimeEntryIterator {
+	return &TimeEntryIterator{pager: c.newPager(ctx, "/time_entries.json", &filter, opts)}
+}
+
+// Next advances to the next time entry. It returns false at the end of
+// the list or when an error occurred, see Err.
+func (it *TimeEntryIterator) Next() bool {
+	return it.next(func(decoder *json.Decoder) (page, int, error) {
+		var r timeEntriesResult
+		err := decoder.Decode(&r)
+		it.entries = r.TimeEntries
+		return r.page, len(r.TimeEntries), err
+	})
+}
+
+// TimeEntry returns the current time entry.
+func (it *TimeEntryIterator) TimeEntry() *TimeEntry {
+	return &it.entries[it.pos]
 }
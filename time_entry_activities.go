@@ -1,9 +1,8 @@
 package redmine
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
-	"strings"
 )
 
 type timeEntryActivitiesResult struct {
@@ -17,7 +16,11 @@ type TimeEntryActivity struct {
 }
 
 func (c *Client) TimeEntryActivities() ([]TimeEntryActivity, error) {
-	res, err := c.Get(c.endpoint + "/enumerations/time_entry_activities.json?key=" + c.apikey + c.getPaginationClause())
+	return c.TimeEntryActivitiesContext(context.Background())
+}
+
+func (c *Client) TimeEntryActivitiesContext(ctx context.Context) ([]TimeEntryActivity, error) {
+	res, err := c.get(ctx, c.endpoint+"/enumerations/time_entry_activities.json")
 	if err != nil {
 		return nil, err
 	}
@@ -26,11 +29,7 @@ func (c *Client) TimeEntryActivities() ([]TimeEntryActivity, error) {
 	decoder := json.NewDecoder(res.Body)
 	var r timeEntryActivitiesResult
 	if res.StatusCode != 200 {
-		var er errorsResult
-		err = decoder.Decode(&er)
-		if err == nil {
-			err = errors.New(strings.Join(er.Errors, "\n"))
-		}
+		err = errorFromResp(res)
 	} else {
 		err = decoder.Decode(&r)
 	}
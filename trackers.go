@@ -1,10 +1,8 @@
 package redmine
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
-	"net/http"
-	"strings"
 )
 
 type trackersResult struct {
@@ -12,7 +10,11 @@ type trackersResult struct {
 }
 
 func (c *Client) Trackers() ([]IdName, error) {
-	res, err := http.Get(c.endpoint + "/trackers.json?key=" + c.apikey + c.getPaginationClause())
+	return c.TrackersContext(context.Background())
+}
+
+func (c *Client) TrackersContext(ctx context.Context) ([]IdName, error) {
+	res, err := c.get(ctx, c.endpoint+"/trackers.json")
 	if err != nil {
 		return nil, err
 	}
@@ -21,11 +23,7 @@ func (c *Client) Trackers() ([]IdName, error) {
 	decoder := json.NewDecoder(res.Body)
 	var r trackersResult
 	if res.StatusCode != 200 {
-		var er errorsResult
-		err = decoder.Decode(&er)
-		if err == nil {
-			err = errors.New(strings.Join(er.Errors, "\n"))
-		}
+		err = errorFromResp(res)
 	} else {
 		err = decoder.Decode(&r)
 	}
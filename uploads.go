@@ -1,12 +1,12 @@
 package redmine
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
-	"errors"
-	"io/ioutil"
+	"io"
 	"net/http"
-	"strings"
+	"os"
+	"path/filepath"
 )
 
 type uploadResponse struct {
@@ -14,40 +14,105 @@ type uploadResponse struct {
 }
 
 type Upload struct {
+	Id          int    `json:"id,omitempty"`
 	Token       string `json:"token"`
 	Filename    string `json:"filename"`
 	ContentType string `json:"content_type"`
+	Description string `json:"description,omitempty"`
 }
 
+// Upload uploads the named file. The returned Upload is attached to an
+// issue by passing it in Issue.Uploads or IssueFields.Uploads.
 func (c *Client) Upload(filename string) (*Upload, error) {
-	content, err := ioutil.ReadFile(filename)
+	return c.UploadContext(context.Background(), filename)
+}
+
+// UploadContext is like Upload but uses ctx for the request.
+func (c *Client) UploadContext(ctx context.Context, filename string) (*Upload, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
 	if err != nil {
 		return nil, err
 	}
-	req, err := http.NewRequest("POST", c.endpoint+"/uploads.json?key="+c.apikey, bytes.NewBuffer(content))
+	return c.UploadReaderContext(ctx, f, fi.Size(), filepath.Base(filename), nil)
+}
+
+// UploadReader uploads size bytes read from r as a file named filename.
+// The content is streamed rather than read into memory. If progress is not
+// nil, it is called as the content is sent with the number of bytes sent so
+// far and size. A negative size stands for an unknown size, in which case
+// r is read until EOF.
+func (c *Client) UploadReader(r io.Reader, size int64, filename string, progress func(sent, total int64)) (*Upload, error) {
+	return c.UploadReaderContext(context.Background(), r, size, filename, progress)
+}
+
+// UploadReaderContext is like UploadReader but uses ctx for the request.
+func (c *Client) UploadReaderContext(ctx context.Context, r io.Reader, size int64, filename string, progress func(sent, total int64)) (*Upload, error) {
+	if progress != nil {
+		r = &progressReader{r: r, total: size, progress: progress}
+	}
+	f := NewFilter()
+	if filename != "" {
+		f.Set("filename", filename)
+	}
+	url, err := c.url("/uploads.json", f)
 	if err != nil {
 		return nil, err
 	}
+	// an empty body must be http.NoBody, net/http takes a zero
+	// ContentLength with another body as an unknown length
+	body := io.Reader(http.NoBody)
+	switch {
+	case size > 0:
+		body = io.LimitReader(r, size)
+	case size < 0:
+		body = r
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", url, body)
+	if err != nil {
+		return nil, err
+	}
+	req.ContentLength = size
 	req.Header.Set("Content-Type", "application/octet-stream")
-	res, err := c.Do(req)
+	res, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
 	defer res.Body.Close()
 
 	decoder := json.NewDecoder(res.Body)
-	var r uploadResponse
+	var u uploadResponse
 	if res.StatusCode != 201 {
-		var er errorsResult
-		err = decoder.Decode(&er)
-		if err == nil {
-			err = errors.New(strings.Join(er.Errors, "\n"))
-		}
+		err = errorFromResp(res)
 	} else {
-		err = decoder.Decode(&r)
+		err = decoder.Decode(&u)
 	}
 	if err != nil {
 		return nil, err
 	}
-	return &r.Upload, nil
+	if u.Upload.Filename == "" {
+		u.Upload.Filename = filename
+	}
+	return &u.Upload, nil
+}
+
+// progressReader reports the number of bytes read from r.
+type progressReader struct {
+	r        io.Reader
+	sent     int64
+	total    int64
+	progress func(sent, total int64)
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	if n > 0 {
+		p.sent += int64(n)
+		p.progress(p.sent, p.total)
+	}
+	return n, err
 }
@@ -1,8 +1,8 @@
 package redmine
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
 	"net/http"
 	"strconv"
 	"strings"
@@ -14,16 +14,20 @@ type userResult struct {
 
 type usersResult struct {
 	Users []User `json:"users"`
+	page
 }
 
 type User struct {
 	Id           int            `json:"id"`
 	Login        string         `json:"login"`
+	Admin        bool           `json:"admin"`
 	Firstname    string         `json:"firstname"`
 	Lastname     string         `json:"lastname"`
 	Mail         string         `json:"mail"`
-	CreatedOn    string         `json:"created_on"`
-	LatLoginOn   string         `json:"last_login_on"`
+	Status       int            `json:"status,omitempty"`
+	ApiKey       string         `json:"api_key,omitempty"` // only shown to admins and the user
+	CreatedOn    DateTime       `json:"created_on"`
+	LatLoginOn   DateTime       `json:"last_login_on"`
 	Memberships  []Membership   `json:"memberships"`
 	CustomFields []*CustomField `json:"custom_fields,omitempty"`
 }
@@ -72,65 +76,115 @@ func (uif *UserByIdFilter) Include(include string) {
 	uif.AddPair("include", include)
 }
 
+func usersFilter(filter *UsersFilter) *Filter {
+	if filter == nil {
+		return nil
+	}
+	return &filter.Filter
+}
+
 func (c *Client) Users() ([]User, error) {
-	res, err := c.Get(c.endpoint + "/users.json?key=" + c.apikey + c.getPaginationClause())
+	return c.UsersContext(context.Background())
+}
+
+func (c *Client) UsersContext(ctx context.Context, opts ...ListOption) ([]User, error) {
+	var list []User
+	err := c.getPages(ctx, "/users.json", nil, opts, func(decoder *json.Decoder) (page, int, error) {
+		var r usersResult
+		err := decoder.Decode(&r)
+		list = append(list, r.Users...)
+		return r.page, len(r.Users), err
+	})
 	if err != nil {
 		return nil, err
 	}
-	defer res.Body.Close()
+	return list, nil
+}
 
-	decoder := json.NewDecoder(res.Body)
-	var r usersResult
-	if res.StatusCode != 200 {
-		var er errorsResult
-		err = decoder.Decode(&er)
-		if err == nil {
-			err = errors.New(strings.Join(er.Errors, "\n"))
-		}
-	} else {
-		err = decoder.Decode(&r)
-	}
+func (c *Client) UsersWithFilter(filter *UsersFilter) ([]User, error) {
+	return c.UsersWithFilterContext(context.Background(), filter)
+}
+
+func (c *Client) UsersWithFilterContext(ctx context.Context, filter *UsersFilter, opts ...ListOption) ([]User, error) {
+	var list []User
+	err := c.getPages(ctx, "/users.json", usersFilter(filter), opts, func(decoder *json.Decoder) (page, int, error) {
+		var r usersResult
+		err := decoder.Decode(&r)
+		list = append(list, r.Users...)
+		return r.page, len(r.Users), err
+	})
 	if err != nil {
 		return nil, err
 	}
-	return r.Users, nil
+	return list, nil
 }
 
-func (c *Client) UsersWithFilter(filter *UsersFilter) ([]User, error) {
-	uri, err := c.URLWithFilter("/users.json", filter.Filter)
+func (c *Client) User(id int) (*User, error) {
+	return c.UserContext(context.Background(), id)
+}
+
+func (c *Client) UserContext(ctx context.Context, id int) (*User, error) {
+	res, err := c.get(ctx, c.endpoint+"/users/"+strconv.Itoa(id)+".json")
 	if err != nil {
 		return nil, err
 	}
-	req, err := http.NewRequest("GET", uri, nil)
+	defer res.Body.Close()
+
+	decoder := json.NewDecoder(res.Body)
+	var r userResult
+	if res.StatusCode != 200 {
+		err = errorFromResp(res)
+	} else {
+		err = decoder.Decode(&r)
+	}
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Add("X-Redmine-API-Key", c.apikey)
-	res, err := c.Do(req)
+	return &r.User, nil
+}
+
+// CurrentUser returns the user the client is authenticated as, including
+// its API key.
+func (c *Client) CurrentUser() (*User, error) {
+	return c.CurrentUserContext(context.Background())
+}
+
+// CurrentUserContext is like CurrentUser but uses ctx for the request.
+func (c *Client) CurrentUserContext(ctx context.Context) (*User, error) {
+	res, err := c.get(ctx, c.endpoint+"/users/current.json")
 	if err != nil {
 		return nil, err
 	}
 	defer res.Body.Close()
 
 	decoder := json.NewDecoder(res.Body)
-	var r usersResult
+	var r userResult
 	if res.StatusCode != 200 {
-		var er errorsResult
-		err = decoder.Decode(&er)
-		if err == nil {
-			err = errors.New(strings.Join(er.Errors, "\n"))
-		}
+		err = errorFromResp(res)
 	} else {
 		err = decoder.Decode(&r)
 	}
 	if err != nil {
 		return nil, err
 	}
-	return r.Users, nil
+	return &r.User, nil
 }
 
-func (c *Client) User(id int) (*User, error) {
-	res, err := c.Get(c.endpoint + "/users/" + strconv.Itoa(id) + ".json?key=" + c.apikey)
+func (c *Client) UserByIdAndFilter(id int, filter *UserByIdFilter) (*User, error) {
+	return c.UserByIdAndFilterContext(context.Background(), id, filter)
+}
+
+func (c *Client) UserByIdAndFilterContext(ctx context.Context, id int, filter *UserByIdFilter) (*User, error) {
+	uri, err := c.URLWithFilter("/users/"+strconv.Itoa(id)+".json", filter.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -139,11 +193,7 @@ func (c *Client) User(id int) (*User, error) {
 	decoder := json.NewDecoder(res.Body)
 	var r userResult
 	if res.StatusCode != 200 {
-		var er errorsResult
-		err = decoder.Decode(&er)
-		if err == nil {
-			err = errors.New(strings.Join(er.Errors, "\n"))
-		}
+		err = errorFromResp(res)
 	} else {
 		err = decoder.Decode(&r)
 	}
@@ -153,18 +203,24 @@ func (c *Client) User(id int) (*User, error) {
 	return &r.User, nil
 }
 
-func (c *Client) UserByIdAndFilter(id int, filter *UserByIdFilter) (*User, error) {
-	uri, err := c.URLWithFilter("/users/"+strconv.Itoa(id)+".json", filter.Filter)
+// CreateUser creates a user with the attributes set in f. Login,
+// firstname, lastname and mail are required, and either a password, a
+// generated password or an authentication source.
+func (c *Client) CreateUser(f *UserFields) (*User, error) {
+	return c.CreateUserContext(context.Background(), f)
+}
+
+func (c *Client) CreateUserContext(ctx context.Context, f *UserFields) (*User, error) {
+	s, err := json.Marshal(userFieldsRequest{User: f, SendInformation: f.sendInformation})
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("GET", uri, nil)
+	req, err := http.NewRequestWithContext(ctx, "POST", c.endpoint+"/users.json", strings.NewReader(string(s)))
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Add("X-Redmine-API-Key", c.apikey)
-	res, err := c.Do(req)
+	req.Header.Set("Content-Type", "application/json")
+	res, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -172,12 +228,8 @@ func (c *Client) UserByIdAndFilter(id int, filter *UserByIdFilter) (*User, error
 
 	decoder := json.NewDecoder(res.Body)
 	var r userResult
-	if res.StatusCode != 200 {
-		var er errorsResult
-		err = decoder.Decode(&er)
-		if err == nil {
-			err = errors.New(strings.Join(er.Errors, "\n"))
-		}
+	if res.StatusCode != 201 {
+		err = errorFromResp(res)
 	} else {
 		err = decoder.Decode(&r)
 	}
@@ -186,3 +238,101 @@ func (c *Client) UserByIdAndFilter(id int, filter *UserByIdFilter) (*User, error
 	}
 	return &r.User, nil
 }
+
+// UpdateUser changes the attributes set in f of the user with the given
+// id, leaving the others unchanged.
+func (c *Client) UpdateUser(id int, f *UserFields) error {
+	return c.UpdateUserContext(context.Background(), id, f)
+}
+
+func (c *Client) UpdateUserContext(ctx context.Context, id int, f *UserFields) error {
+	s, err := json.Marshal(userFieldsRequest{User: f, SendInformation: f.sendInformation})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, "PUT", c.endpoint+"/users/"+strconv.Itoa(id)+".json", strings.NewReader(string(s)))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode/100 != 2 {
+		return errorFromResp(res)
+	}
+	return nil
+}
+
+// LockUser locks the user with the given id, who can then no longer log
+// in nor use the API.
+func (c *Client) LockUser(id int) error {
+	return c.LockUserContext(context.Background(), id)
+}
+
+// LockUserContext is like LockUser but uses ctx for the request.
+func (c *Client) LockUserContext(ctx context.Context, id int) error {
+	return c.UpdateUserContext(ctx, id, NewUserFields().Status(UserStatusLocked))
+}
+
+// UnlockUser activates the user with the given id.
+func (c *Client) UnlockUser(id int) error {
+	return c.UnlockUserContext(context.Background(), id)
+}
+
+// UnlockUserContext is like UnlockUser but uses ctx for the request.
+func (c *Client) UnlockUserContext(ctx context.Context, id int) error {
+	return c.UpdateUserContext(ctx, id, NewUserFields().Status(UserStatusActive))
+}
+
+func (c *Client) DeleteUser(id int) error {
+	return c.DeleteUserContext(context.Background(), id)
+}
+
+func (c *Client) DeleteUserContext(ctx context.Context, id int) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", c.endpoint+"/users/"+strconv.Itoa(id)+".json", strings.NewReader(""))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode/100 != 2 {
+		return errorFromResp(res)
+	}
+	return nil
+}
+
+// UserIterator walks through users page by page.
+type UserIterator struct {
+	pager
+	users []User
+}
+
+// IterUsers returns an iterator over the users matching filter.
+func (c *Client) IterUsers(ctx context.Context, filter *UsersFilter, opts ...ListOption) *UserIterator {
+	return &UserIterator{pager: c.newPager(ctx, "/users.json", usersFilter(filter), opts)}
+}
+
+// Next advances to the next user. It returns false at the end of
+// the list or when an error occurred, see Err.
+func (it *UserIterator) Next() bool {
+	return it.next(func(decoder *json.Decoder) (page, int, error) {
+		var r usersResult
+		err := decoder.Decode(&r)
+		it.users = r.Users
+		return r.page, len(r.Users), err
+	})
+}
+
+// User returns the current user.
+func (it *UserIterator) User() *User {
+	return &it.users[it.pos]
+}
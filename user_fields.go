@@ -0,0 +1,94 @@
+package redmine
+
+// UserFields holds the user attributes sent by CreateUser and UpdateUser.
+// Like IssueFields, only the attributes which are set are sent:
+//
+//	f := redmine.NewUserFields().
+//		Login("jdoe").
+//		Firstname("John").
+//		Lastname("Doe").
+//		Mail("jdoe@example.com").
+//		GeneratePassword().
+//		SendInformation()
+//	user, err := c.CreateUser(f)
+type UserFields struct {
+	attributes
+	sendInformation bool
+}
+
+type userFieldsRequest struct {
+	User            *UserFields `json:"user"`
+	SendInformation bool        `json:"send_information,omitempty"`
+}
+
+// NewUserFields returns an empty set of user attributes.
+func NewUserFields() *UserFields {
+	return &UserFields{}
+}
+
+// Set sets the user attribute name, e.g. "login" or "mail", to value.
+func (f *UserFields) Set(name string, value interface{}) *UserFields {
+	f.set(name, value)
+	return f
+}
+
+// Clear empties the user attribute name, e.g. "auth_source_id".
+func (f *UserFields) Clear(name string) *UserFields {
+	return f.Set(name, "")
+}
+
+// Unset removes the user attribute name, see IssueFields.Unset.
+func (f *UserFields) Unset(name string) *UserFields {
+	f.unset(name)
+	return f
+}
+
+// Has reports whether the user attribute name is set or cleared.
+func (f *UserFields) Has(name string) bool {
+	return f.has(name)
+}
+
+func (f *UserFields) Login(s string) *UserFields     { return f.Set("login", s) }
+func (f *UserFields) Firstname(s string) *UserFields { return f.Set("firstname", s) }
+func (f *UserFields) Lastname(s string) *UserFields  { return f.Set("lastname", s) }
+func (f *UserFields) Mail(s string) *UserFields      { return f.Set("mail", s) }
+func (f *UserFields) Admin(b bool) *UserFields       { return f.Set("admin", b) }
+func (f *UserFields) Password(s string) *UserFields  { return f.Set("password", s) }
+func (f *UserFields) AuthSource(id int) *UserFields  { return f.Set("auth_source_id", id) }
+
+// Status sets the status of the user, see UserStatusActive and
+// UserStatusLocked.
+func (f *UserFields) Status(status string) *UserFields {
+	return f.Set("status", status)
+}
+
+// GeneratePassword makes Redmine generate a random password.
+func (f *UserFields) GeneratePassword() *UserFields {
+	return f.Set("generate_password", true)
+}
+
+// MustChangePasswd makes the user change the password at the next login.
+func (f *UserFields) MustChangePasswd(b bool) *UserFields {
+	return f.Set("must_change_passwd", b)
+}
+
+// MailNotification sets which events the user is notified of by mail,
+// e.g. "all", "selected", "only_my_events", "only_assigned",
+// "only_owner" or "none".
+func (f *UserFields) MailNotification(s string) *UserFields {
+	return f.Set("mail_notification", s)
+}
+
+// SendInformation mails the account information, including a generated
+// password, to the user.
+func (f *UserFields) SendInformation() *UserFields {
+	f.sendInformation = true
+	return f
+}
+
+// CustomField sets the value of the custom field with the given id, "" to
+// clear it.
+func (f *UserFields) CustomField(id int, value interface{}) *UserFields {
+	f.setCustomField(id, value)
+	return f
+}
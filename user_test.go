@@ -0,0 +1,60 @@
+package redmine
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestUserWrites(t *testing.T) {
+	var method, path, body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		method, path, body = r.Method, r.URL.Path, string(b)
+		if r.Method == "POST" {
+			w.WriteHeader(http.StatusCreated)
+			io.WriteString(w, `{"user":{"id":3}}`)
+		}
+	}))
+	defer srv.Close()
+	c := NewClient(srv.URL, "key")
+
+	tests := []struct {
+		call               func() error
+		method, path, want string
+	}{
+		{
+			func() error { return c.UpdateUser(3, NewUserFields().Mail("a@b")) },
+			"PUT", "/users/3.json", `{"user":{"mail":"a@b"}}`,
+		},
+		{
+			func() error { return c.UpdateUser(3, NewUserFields().Admin(false).Clear("auth_source_id")) },
+			"PUT", "/users/3.json", `{"user":{"admin":false,"auth_source_id":""}}`,
+		},
+		{
+			func() error { return c.LockUser(3) },
+			"PUT", "/users/3.json", `{"user":{"status":"3"}}`,
+		},
+		{
+			func() error { return c.UnlockUser(3) },
+			"PUT", "/users/3.json", `{"user":{"status":"1"}}`,
+		},
+		{
+			func() error {
+				_, err := c.CreateUser(NewUserFields().Login("jdoe").GeneratePassword().SendInformation())
+				return err
+			},
+			"POST", "/users.json", `{"user":{"generate_password":true,"login":"jdoe"},"send_information":true}`,
+		},
+	}
+	for _, tt := range tests {
+		if err := tt.call(); err != nil {
+			t.Fatal(err)
+		}
+		if method != tt.method || path != tt.path || body != tt.want {
+			t.Errorf("sent %s %s %s, want %s %s %s", method, path, body, tt.method, tt.path, tt.want)
+		}
+	}
+}
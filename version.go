@@ -1,8 +1,8 @@
 package redmine
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
 	"net/http"
 	"strconv"
 	"strings"
@@ -18,6 +18,7 @@ type versionResult struct {
 
 type versionsResult struct {
 	Versions []Version `json:"versions"`
+	page
 }
 
 type Version struct {
@@ -26,31 +27,27 @@ type Version struct {
 	Name         string         `json:"name"`
 	Description  string         `json:"description"`
 	Status       string         `json:"status"`
-	DueDate      string         `json:"due_date"`
-	CreatedOn    string         `json:"created_on"`
-	UpdatedOn    string         `json:"updated_on"`
+	DueDate      Date           `json:"due_date"`
+	CreatedOn    DateTime       `json:"created_on"`
+	UpdatedOn    DateTime       `json:"updated_on"`
 	CustomFields []*CustomField `json:"custom_fields,omitempty"`
 }
 
 func (c *Client) Version(id int) (*Version, error) {
-	res, err := c.Get(c.endpoint + "/versions/" + strconv.Itoa(id) + ".json?key=" + c.apikey)
+	return c.VersionContext(context.Background(), id)
+}
+
+func (c *Client) VersionContext(ctx context.Context, id int) (*Version, error) {
+	res, err := c.get(ctx, c.endpoint+"/versions/"+strconv.Itoa(id)+".json")
 	if err != nil {
 		return nil, err
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode == 404 {
-		return nil, errors.New("Not Found")
-	}
-
 	decoder := json.NewDecoder(res.Body)
 	var r versionResult
 	if res.StatusCode != 200 {
-		var er errorsResult
-		err = decoder.Decode(&er)
-		if err == nil {
-			err = errors.New(strings.Join(er.Errors, "\n"))
-		}
+		err = errorFromResp(res)
 	} else {
 		err = decoder.Decode(&r)
 	}
@@ -61,63 +58,49 @@ func (c *Client) Version(id int) (*Version, error) {
 }
 
 func (c *Client) Versions(projectId int) ([]Version, error) {
-	res, err := c.Get(c.endpoint + "/projects/" + strconv.Itoa(projectId) + "/versions.json?key=" + c.apikey + c.getPaginationClause())
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-
-	if res.StatusCode == 404 {
-		return nil, errors.New("Not Found")
-	}
+	return c.VersionsContext(context.Background(), projectId)
+}
 
-	decoder := json.NewDecoder(res.Body)
-	var r versionsResult
-	if res.StatusCode != 200 {
-		var er errorsResult
-		err = decoder.Decode(&er)
-		if err == nil {
-			err = errors.New(strings.Join(er.Errors, "\n"))
-		}
-	} else {
-		err = decoder.Decode(&r)
-	}
+func (c *Client) VersionsContext(ctx context.Context, projectId int, opts ...ListOption) ([]Version, error) {
+	var list []Version
+	err := c.getPages(ctx, "/projects/"+strconv.Itoa(projectId)+"/versions.json", nil, opts, func(decoder *json.Decoder) (page, int, error) {
+		var r versionsResult
+		err := decoder.Decode(&r)
+		list = append(list, r.Versions...)
+		return r.page, len(r.Versions), err
+	})
 	if err != nil {
 		return nil, err
 	}
-	return r.Versions, nil
+	return list, nil
 }
 
 func (c *Client) CreateVersion(version Version) (*Version, error) {
+	return c.CreateVersionContext(context.Background(), version)
+}
+
+func (c *Client) CreateVersionContext(ctx context.Context, version Version) (*Version, error) {
 	var ir versionRequest
 	ir.Version = version
 	s, err := json.Marshal(ir)
 	if err != nil {
 		return nil, err
 	}
-	req, err := http.NewRequest("POST", c.endpoint+"/projects/"+strconv.Itoa(version.Project.Id)+"/versions.json?key="+c.apikey, strings.NewReader(string(s)))
+	req, err := http.NewRequestWithContext(ctx, "POST", c.endpoint+"/projects/"+strconv.Itoa(version.Project.Id)+"/versions.json", strings.NewReader(string(s)))
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	res, err := c.Do(req)
+	res, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode == 404 {
-		return nil, errors.New("Not Found")
-	}
-
 	decoder := json.NewDecoder(res.Body)
 	var r versionRequest
 	if res.StatusCode != 201 {
-		var er errorsResult
-		err = decoder.Decode(&er)
-		if err == nil {
-			err = errors.New(strings.Join(er.Errors, "\n"))
-		}
+		err = errorFromResp(res)
 	} else {
 		err = decoder.Decode(&r)
 	}
@@ -128,57 +111,78 @@ func (c *Client) CreateVersion(version Version) (*Version, error) {
 }
 
 func (c *Client) UpdateVersion(version Version) error {
+	return c.UpdateVersionContext(context.Background(), version)
+}
+
+func (c *Client) UpdateVersionContext(ctx context.Context, version Version) error {
 	var ir versionRequest
 	ir.Version = version
 	s, err := json.Marshal(ir)
 	if err != nil {
 		return err
 	}
-	req, err := http.NewRequest("PUT", c.endpoint+"/versions/"+strconv.Itoa(version.Id)+".json?key="+c.apikey, strings.NewReader(string(s)))
+	req, err := http.NewRequestWithContext(ctx, "PUT", c.endpoint+"/versions/"+strconv.Itoa(version.Id)+".json", strings.NewReader(string(s)))
 	if err != nil {
 		return err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	res, err := c.Do(req)
+	res, err := c.do(req)
 	if err != nil {
 		return err
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode == 404 {
-		return errors.New("Not Found")
+	if res.StatusCode/100 != 2 {
+		return errorFromResp(res)
 	}
-	if res.StatusCode != 200 {
-		var er errorsResult
-		err = json.NewDecoder(res.Body).Decode(&er)
-		if err == nil {
-			err = errors.New(strings.Join(er.Errors, "\n"))
-		}
-	}
-	return err
+	return nil
 }
 
 func (c *Client) DeleteVersion(id int) error {
-	req, err := http.NewRequest("DELETE", c.endpoint+"/versions/"+strconv.Itoa(id)+".json?key="+c.apikey, strings.NewReader(""))
+	return c.DeleteVersionContext(context.Background(), id)
+}
+
+func (c *Client) DeleteVersionContext(ctx context.Context, id int) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", c.endpoint+"/versions/"+strconv.Itoa(id)+".json", strings.NewReader(""))
 	if err != nil {
 		return err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	res, err := c.Do(req)
+	res, err := c.do(req)
 	if err != nil {
 		return err
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode == 404 {
-		return errors.New("Not Found")
-	}
-	if res.StatusCode != 200 {
-		var er errorsResult
-		err = json.NewDecoder(res.Body).Decode(&er)
-		if err == nil {
-			err = errors.New(strings.Join(er.Errors, "\n"))
-		}
+	if res.StatusCode/100 != 2 {
+		return errorFromResp(res)
 	}
-	return err
+	return nil
+}
+
+// VersionIterator walks through versions page by page.
+type VersionIterator struct {
+	pager
+	versions []Version
+}
+
+// IterVersions returns an iterator over the versions of the given project.
+func (c *Client) IterVersions(ctx context.Context, projectId int, opts ...ListOption) *VersionIterator {
+	return &VersionIterator{pager: c.newPager(ctx, "/projects/"+strconv.Itoa(projectId)+"/versions.json", nil, opts)}
+}
+
+// Next advances to the next version. It returns false at the end of
+// the list or when an error occurred, see Err.
+func (it *VersionIterator) Next() bool {
+	return it.next(func(decoder *json.Decoder) (page, int, error) {
+		var r versionsResult
+		err := decoder.Decode(&r)
+		it.versions = r.Versions
+		return r.page, len(r.Versions), err
+	})
+}
+
+// Version returns the current version.
+func (it *VersionIterator) Version() *Version {
+	return &it.versions[it.pos]
 }
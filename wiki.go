@@ -1,8 +1,8 @@
 package redmine
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
 	"net/http"
 	"strconv"
 	"strings"
@@ -10,6 +10,7 @@ import (
 
 type wikiPagesResult struct {
 	WikiPages []WikiPage `json:"wiki_pages"`
+	page
 }
 
 type wikiPageResult struct {
@@ -27,8 +28,8 @@ type WikiPage struct {
 	Version   interface{} `json:"version,omitempty"`
 	Author    *IdName     `json:"author,omitempty"`
 	Comments  string      `json:"comments"`
-	CreatedOn string      `json:"created_on,omitempty"`
-	UpdatedOn string      `json:"updated_on,omitempty"`
+	CreatedOn DateTime    `json:"created_on"`
+	UpdatedOn DateTime    `json:"updated_on"`
 	ParentID  int         `json:"parent_id"`
 }
 
@@ -39,43 +40,46 @@ type Parent struct {
 // WikiPages fetches a list of all wiki pages of the given project.
 // The Text field of the listed pages is not fetch by this command and is thus empty.
 func (c *Client) WikiPages(projectId int) ([]WikiPage, error) {
-	res, err := c.Get(c.endpoint + "/projects/" + strconv.Itoa(projectId) + "/wiki/index.json?key=" + c.apikey + c.getPaginationClause())
+	return c.WikiPagesContext(context.Background(), projectId)
+}
+
+// WikiPagesContext is like WikiPages but uses ctx for the request.
+func (c *Client) WikiPagesContext(ctx context.Context, projectId int, opts ...ListOption) ([]WikiPage, error) {
+	var list []WikiPage
+	err := c.getPages(ctx, "/projects/"+strconv.Itoa(projectId)+"/wiki/index.json", nil, opts, func(decoder *json.Decoder) (page, int, error) {
+		var r wikiPagesResult
+		err := decoder.Decode(&r)
+		list = append(list, r.WikiPages...)
+		return r.page, len(r.WikiPages), err
+	})
 	if err != nil {
 		return nil, err
 	}
-	defer res.Body.Close()
-
-	decoder := json.NewDecoder(res.Body)
-	var r wikiPagesResult
-	if res.StatusCode == 404 {
-		return nil, errors.New("Not Found")
-	}
-	if res.StatusCode != 200 {
-		var er errorsResult
-		if err = decoder.Decode(&er); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.Join(er.Errors, "\n"))
-	} else {
-		if err = decoder.Decode(&r); err != nil {
-			return nil, err
-		}
-	}
-	return r.WikiPages, nil
+	return list, nil
 }
 
 // WikiPage fetches the wiki page with the given title.
 func (c *Client) WikiPage(projectId int, title string) (*WikiPage, error) {
-	return c.getWikiPage(projectId, title)
+	return c.WikiPageContext(context.Background(), projectId, title)
+}
+
+// WikiPageContext is like WikiPage but uses ctx for the request.
+func (c *Client) WikiPageContext(ctx context.Context, projectId int, title string) (*WikiPage, error) {
+	return c.getWikiPage(ctx, projectId, title)
 }
 
 // WikiPageAtVersion fetches the wiki page with the given title at the given version.
 func (c *Client) WikiPageAtVersion(projectId int, title string, version string) (*WikiPage, error) {
-	return c.getWikiPage(projectId, title+"/"+version)
+	return c.WikiPageAtVersionContext(context.Background(), projectId, title, version)
+}
+
+// WikiPageAtVersionContext is like WikiPageAtVersion but uses ctx for the request.
+func (c *Client) WikiPageAtVersionContext(ctx context.Context, projectId int, title string, version string) (*WikiPage, error) {
+	return c.getWikiPage(ctx, projectId, title+"/"+version)
 }
 
-func (c *Client) getWikiPage(projectId int, resource string) (*WikiPage, error) {
-	res, err := c.Get(c.endpoint + "/projects/" + strconv.Itoa(projectId) + "/wiki/" + resource + ".json?key=" + c.apikey)
+func (c *Client) getWikiPage(ctx context.Context, projectId int, resource string) (*WikiPage, error) {
+	res, err := c.get(ctx, c.endpoint+"/projects/"+strconv.Itoa(projectId)+"/wiki/"+resource+".json")
 	if err != nil {
 		return nil, err
 	}
@@ -83,15 +87,8 @@ func (c *Client) getWikiPage(projectId int, resource string) (*WikiPage, error)
 
 	decoder := json.NewDecoder(res.Body)
 	var r wikiPageResult
-	if res.StatusCode == 404 {
-		return nil, errors.New("Not Found")
-	}
 	if res.StatusCode != 200 {
-		var er errorsResult
-		if err = decoder.Decode(&er); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.Join(er.Errors, "\n"))
+		return nil, errorFromResp(res)
 	} else {
 		if err = decoder.Decode(&r); err != nil {
 			return nil, err
@@ -102,18 +99,23 @@ func (c *Client) getWikiPage(projectId int, resource string) (*WikiPage, error)
 
 // CreateWikiPage creates wiki page.
 func (c *Client) CreateWikiPage(projectId int, wikiPage WikiPage) (*WikiPage, error) {
+	return c.CreateWikiPageContext(context.Background(), projectId, wikiPage)
+}
+
+// CreateWikiPageContext is like CreateWikiPage but uses ctx for the request.
+func (c *Client) CreateWikiPageContext(ctx context.Context, projectId int, wikiPage WikiPage) (*WikiPage, error) {
 	var wpr wikiPageRequest
 	wpr.WikiPage = wikiPage
 	s, err := json.Marshal(wpr)
 	if err != nil {
 		return nil, err
 	}
-	req, err := http.NewRequest("PUT", c.endpoint+"/projects/"+strconv.Itoa(projectId)+"/wiki/"+wikiPage.Title+".json?key="+c.apikey, strings.NewReader(string(s)))
+	req, err := http.NewRequestWithContext(ctx, "PUT", c.endpoint+"/projects/"+strconv.Itoa(projectId)+"/wiki/"+wikiPage.Title+".json", strings.NewReader(string(s)))
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	res, err := c.Do(req)
+	res, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -122,11 +124,7 @@ func (c *Client) CreateWikiPage(projectId int, wikiPage WikiPage) (*WikiPage, er
 	decoder := json.NewDecoder(res.Body)
 	var r wikiPageResult
 	if res.StatusCode != 201 {
-		var er errorsResult
-		if err = decoder.Decode(&er); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.Join(er.Errors, "\n"))
+		return nil, errorFromResp(res)
 	} else {
 		if err := decoder.Decode(&r); err != nil {
 			return nil, err
@@ -137,61 +135,54 @@ func (c *Client) CreateWikiPage(projectId int, wikiPage WikiPage) (*WikiPage, er
 
 // UpdateWikiPage updates the wiki page given by the Title field of wikiPage.
 func (c *Client) UpdateWikiPage(projectId int, wikiPage WikiPage) error {
+	return c.UpdateWikiPageContext(context.Background(), projectId, wikiPage)
+}
+
+// UpdateWikiPageContext is like UpdateWikiPage but uses ctx for the request.
+func (c *Client) UpdateWikiPageContext(ctx context.Context, projectId int, wikiPage WikiPage) error {
 	var wpr wikiPageRequest
 	wpr.WikiPage = wikiPage
 	s, err := json.Marshal(wpr)
 	if err != nil {
 		return err
 	}
-	req, err := http.NewRequest("PUT", c.endpoint+"/projects/"+strconv.Itoa(projectId)+"/wiki/"+wikiPage.Title+".json?key="+c.apikey, strings.NewReader(string(s)))
+	req, err := http.NewRequestWithContext(ctx, "PUT", c.endpoint+"/projects/"+strconv.Itoa(projectId)+"/wiki/"+wikiPage.Title+".json", strings.NewReader(string(s)))
 	if err != nil {
 		return err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	res, err := c.Do(req)
+	res, err := c.do(req)
 	if err != nil {
 		return err
 	}
 	defer res.Body.Close()
-	if res.StatusCode == 404 {
-		return errors.New("Not Found")
-	}
 
-	if res.StatusCode != 200 {
-		decoder := json.NewDecoder(res.Body)
-		var er errorsResult
-		if err := decoder.Decode(&er); err != nil {
-			return err
-		}
-		return errors.New(strings.Join(er.Errors, "\n"))
+	if res.StatusCode/100 != 2 {
+		return errorFromResp(res)
 	}
 	return nil
 }
 
 // DeleteWikiPage deletes the wiki page given by its title irreversibly.
 func (c *Client) DeleteWikiPage(projectId int, title string) error {
-	req, err := http.NewRequest("DELETE", c.endpoint+"/projects/"+strconv.Itoa(projectId)+"/wiki/"+title+".json?key="+c.apikey, strings.NewReader(""))
+	return c.DeleteWikiPageContext(context.Background(), projectId, title)
+}
+
+// DeleteWikiPageContext is like DeleteWikiPage but uses ctx for the request.
+func (c *Client) DeleteWikiPageContext(ctx context.Context, projectId int, title string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", c.endpoint+"/projects/"+strconv.Itoa(projectId)+"/wiki/"+title+".json", strings.NewReader(""))
 	if err != nil {
 		return err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	res, err := c.Do(req)
+	res, err := c.do(req)
 	if err != nil {
 		return err
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode == 404 {
-		return errors.New("Not Found")
-	}
-
-	decoder := json.NewDecoder(res.Body)
-	if res.StatusCode != 200 {
-		var er errorsResult
-		if err := decoder.Decode(&er); err != nil {
-			return err
-		}
-		return errors.New(strings.Join(er.Errors, "\n"))
+	if res.StatusCode/100 != 2 {
+		return errorFromResp(res)
 	}
 	return nil
 }